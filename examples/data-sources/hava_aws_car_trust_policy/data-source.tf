@@ -0,0 +1,10 @@
+data "hava_aws_car_trust_policy" "example" {
+  external_id              = var.external_id
+  include_managed_policies = true
+}
+
+resource "aws_iam_role" "hava_ro" {
+  name                = "hava-read-only-role"
+  assume_role_policy  = data.hava_aws_car_trust_policy.example.json
+  managed_policy_arns = data.hava_aws_car_trust_policy.example.managed_policy_arns
+}
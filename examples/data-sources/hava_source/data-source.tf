@@ -0,0 +1,3 @@
+data "hava_source" "shared" {
+  name = "Shared Services"
+}
@@ -0,0 +1,5 @@
+data "hava_sources" "aws" {
+  type       = "AWS::CrossAccountRole"
+  state      = "active"
+  name_regex = "^prod-"
+}
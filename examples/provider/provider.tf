@@ -1,4 +1,4 @@
 provider "hava" {
-  // alternatively use the HAVA_TOKEN environment variable
+  // alternatively use the HAVA_TOKEN environment variable, or a profile in ~/.hava/credentials
   api_token = "xxx"
 }
\ No newline at end of file
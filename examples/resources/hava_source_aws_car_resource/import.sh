@@ -0,0 +1,2 @@
+# Sources can be imported using the ID of the source in Hava
+terraform import hava_source_aws_car_resource.example 046b6c7f-0b8a-43b9-b35d-6489e6daee91
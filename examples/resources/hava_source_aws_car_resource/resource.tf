@@ -1,5 +1,5 @@
 resource "hava_source_aws_car_resource" "example" {
   name        = "Example Source"
-  role_arn    = "arn:aws:iam::12341234:role/HavaRO"
+  role_arn    = "arn:aws:iam::123456789012:role/HavaRO"
   external_id = "0934086b5ab9970205878266249aebd9"
 }
\ No newline at end of file
@@ -1,7 +1,7 @@
 resource "hava_source_azure_credentials_resource" "example" {
   name            = "Example Source"
-  subscription_id = "xxx"
-  tenant_id       = "xxx"
-  client_id       = "xxx"
+  subscription_id = "00000000-0000-0000-0000-000000000000"
+  tenant_id       = "00000000-0000-0000-0000-000000000000"
+  client_id       = "00000000-0000-0000-0000-000000000000"
   secret_key      = "xxx"
 }
\ No newline at end of file
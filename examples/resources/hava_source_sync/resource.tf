@@ -0,0 +1,10 @@
+resource "hava_source_sync" "example" {
+  source_id = hava_source.example.id
+
+  # sync the source again whenever the infrastructure it imports changes
+  triggers = {
+    vpc_id = aws_vpc.main.id
+  }
+
+  wait_for_completion = true
+}
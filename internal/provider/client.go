@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	havaclient "github.com/teamhava/hava-sdk-go"
+)
+
+// Descriptions of the provider attributes that configure how the Hava API is connected to,
+// shared by the SDKv2 and framework provider schemas
+const (
+	caBundleFileDescription       = "Path of a PEM encoded CA bundle to trust in addition to the system certificate authorities, e.g. for a self-hosted Hava behind an internal PKI."
+	clientCertFileDescription     = "Path of a PEM encoded client certificate to authenticate to the Hava API with mutual TLS. Requires `client_key_file`."
+	clientKeyFileDescription      = "Path of the PEM encoded private key of the client certificate. Requires `client_cert_file`."
+	proxyURLDescription           = "URL of the proxy to send requests to the Hava API through, such as `http://proxy.example.com:3128`. Defaults to the proxy set by the `HTTPS_PROXY` and `NO_PROXY` environment variables."
+	insecureSkipVerifyDescription = "Don't verify the TLS certificate of the Hava API. This exposes the API token to anyone able to intercept the connection, so only use it for testing. Defaults to `false`."
+)
+
+// providerConfig is the provider configuration once defaults and environment variables
+// have been applied. Both the SDKv2 and the framework provider build their API client from
+// it, so resources served by either of them talk to Hava the same way.
+type providerConfig struct {
+	APIToken     string
+	Endpoint     string
+	MaxRetries   int
+	RetryMaxWait time.Duration
+
+	RequestsPerSecond     float64
+	MaxConcurrentRequests int
+
+	CABundleFile       string
+	ClientCertFile     string
+	ClientKeyFile      string
+	ProxyURL           string
+	InsecureSkipVerify bool
+}
+
+func (c providerConfig) client(ctx context.Context, userAgent string) (*havaclient.APIClient, error) {
+	if c.APIToken == "" {
+		return nil, fmt.Errorf("api token not found, did you set the 'HAVA_TOKEN' environment variable or a profile in the credentials file")
+	}
+
+	cfg := havaclient.NewConfiguration()
+	cfg.Servers = havaclient.ServerConfigurations{
+		{
+			URL:         c.Endpoint,
+			Description: "No description provided",
+		},
+	}
+
+	cfg.UserAgent = userAgent
+
+	httpClient, err := c.httpClient(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.HTTPClient = httpClient
+
+	cfg.DefaultHeader["Authorization"] = "Bearer " + c.APIToken
+
+	return havaclient.NewAPIClient(cfg), nil
+}
+
+var (
+	httpClientsMu sync.Mutex
+	httpClients   = map[providerConfig]*http.Client{}
+)
+
+// httpClient returns the HTTP client for this configuration. The SDKv2 and framework
+// providers are configured separately but run in the same process, so they share one HTTP
+// client, and with it the rate limits, for the same configuration.
+func (c providerConfig) httpClient(ctx context.Context) (*http.Client, error) {
+	httpClientsMu.Lock()
+	defer httpClientsMu.Unlock()
+
+	if client, ok := httpClients[c]; ok {
+		return client, nil
+	}
+
+	transport, err := c.baseTransport(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if httpLoggingEnabled() {
+		transport = newLoggingTransport(transport)
+	}
+
+	transport = newRateLimitTransport(transport, c.RequestsPerSecond, c.MaxConcurrentRequests)
+	transport = newRetryTransport(transport, c.MaxRetries, c.RetryMaxWait)
+
+	client := &http.Client{
+		Transport: transport,
+	}
+
+	httpClients[c] = client
+
+	return client, nil
+}
+
+// baseTransport returns the transport sending requests to Hava, using the TLS and proxy
+// options for self-hosted endpoints when they are set
+func (c providerConfig) baseTransport(ctx context.Context) (http.RoundTripper, error) {
+	if c.CABundleFile == "" && c.ClientCertFile == "" && c.ProxyURL == "" && !c.InsecureSkipVerify {
+		return http.DefaultTransport, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if c.CABundleFile != "" {
+		pem, err := os.ReadFile(c.CABundleFile)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the CA bundle: %w", err)
+		}
+
+		// trust the private CA in addition to the system roots, so a proxy using a public
+		// certificate keeps working
+		pool, err := x509.SystemCertPool()
+
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("the CA bundle %s doesn't contain any PEM encoded certificates", c.CABundleFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if c.InsecureSkipVerify {
+		tflog.Warn(ctx, "insecure_skip_verify is set, the TLS certificate of the Hava API is not verified. Only use this for testing, as it exposes the API token to anyone able to intercept the connection.")
+
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	if c.ProxyURL != "" {
+		proxy, err := url.Parse(c.ProxyURL)
+
+		if err != nil || proxy.Scheme == "" || proxy.Host == "" {
+			return nil, fmt.Errorf("the proxy URL %q is not a valid URL, such as http://proxy.example.com:3128", c.ProxyURL)
+		}
+
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	return transport, nil
+}
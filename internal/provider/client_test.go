@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestProviderConfigHTTPClient_tls(t *testing.T) {
+	dir := t.TempDir()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caBundle := filepath.Join(dir, "ca.pem")
+	writePEM(t, caBundle, "CERTIFICATE", server.Certificate().Raw)
+
+	clientCert, clientKey := writeClientCertificate(t, dir)
+
+	cases := map[string]struct {
+		config     providerConfig
+		wantStatus int
+		wantErr    bool
+	}{
+		"untrusted certificate": {
+			config:  providerConfig{},
+			wantErr: true,
+		},
+		"CA bundle": {
+			config:     providerConfig{CABundleFile: caBundle},
+			wantStatus: http.StatusUnauthorized,
+		},
+		"client certificate": {
+			config:     providerConfig{CABundleFile: caBundle, ClientCertFile: clientCert, ClientKeyFile: clientKey},
+			wantStatus: http.StatusNoContent,
+		},
+		"insecure skip verify": {
+			config:     providerConfig{InsecureSkipVerify: true},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client, err := tc.config.httpClient(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			res, err := client.Get(server.URL)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("expected the request to fail")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			res.Body.Close()
+
+			if res.StatusCode != tc.wantStatus {
+				t.Errorf("expected status %d, got %d", tc.wantStatus, res.StatusCode)
+			}
+		})
+	}
+}
+
+func TestProviderConfigHTTPClient_proxy(t *testing.T) {
+	proxied := false
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.Host == "hava.example.com"
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(proxy.Close)
+
+	client, err := providerConfig{ProxyURL: proxy.URL}.httpClient(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	res, err := client.Get("http://hava.example.com/sources")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	res.Body.Close()
+
+	if !proxied {
+		t.Error("expected the request to go through the proxy")
+	}
+}
+
+func TestProviderConfigHTTPClient_invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	notPEM := filepath.Join(dir, "ca.pem")
+
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, config := range map[string]providerConfig{
+		"missing CA bundle":          {CABundleFile: filepath.Join(dir, "missing.pem")},
+		"CA bundle without PEM":      {CABundleFile: notPEM},
+		"missing client certificate": {ClientCertFile: filepath.Join(dir, "missing.pem"), ClientKeyFile: filepath.Join(dir, "missing.key")},
+		"invalid proxy URL":          {ProxyURL: "proxy.example.com:3128"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := config.httpClient(context.Background()); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+// writeClientCertificate writes a self-signed client certificate and its key to dir
+func writeClientCertificate(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client.key")
+
+	writePEM(t, certFile, "CERTIFICATE", cert)
+	writePEM(t, keyFile, "EC PRIVATE KEY", der)
+
+	return certFile, keyFile
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) {
+	t.Helper()
+
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
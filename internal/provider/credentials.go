@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultEndpoint = "https://api.hava.io"
+	defaultProfile  = "default"
+)
+
+// Descriptions of the provider attributes that select the credentials, shared by the SDKv2
+// and framework provider schemas
+const (
+	apiTokenDescription        = "The API token to authenticate with the Hava API. This takes precedence over profiles and the `HAVA_TOKEN` environment variable."
+	apiTokenCommandDescription = "Command that prints the API token to authenticate with the Hava API, e.g. to read it from a secrets vault. The command is run by the shell, once per Terraform run, and its output is used as the token. This takes precedence over profiles and the `HAVA_TOKEN` environment variable, but not over `api_token`."
+	endpointDescription        = "Which API endpoint to connect to. This is primarily used to support self-hosted users that does not use the default SaaS API endpoints. This takes precedence over profiles and the `HAVA_ENDPOINT` environment variable. Defaults to `https://api.hava.io`."
+	profileDescription         = "Name of the profile in the credentials file to read the API token and endpoint from. Can also be set with the `HAVA_PROFILE` environment variable. A selected profile takes precedence over the `HAVA_TOKEN` and `HAVA_ENDPOINT` environment variables, otherwise the `default` profile is used if the credentials file exists."
+	configFileDescription      = "Path of the credentials file holding the profiles. Defaults to `~/.hava/credentials`."
+)
+
+// credentialsProfile is a named profile in the credentials file
+type credentialsProfile struct {
+	APIToken        string
+	APITokenCommand string
+	Endpoint        string
+}
+
+// credentialSettings are the provider attributes that determine which token and endpoint are
+// used. Empty values are not set in the provider configuration.
+type credentialSettings struct {
+	APIToken        string
+	APITokenCommand string
+	Endpoint        string
+	Profile         string
+	ConfigFile      string
+}
+
+// resolve returns the API token and endpoint to use, in order of precedence from
+//
+//  1. the api_token, api_token_command and endpoint attributes
+//  2. the profile set by the profile attribute or the HAVA_PROFILE environment variable
+//  3. the HAVA_TOKEN and HAVA_ENDPOINT environment variables
+//  4. the default profile, if the credentials file exists
+//
+// falling back to the Hava SaaS endpoint. Each value is resolved on its own, so a profile can
+// for instance provide the endpoint while the token comes from HAVA_TOKEN. A token command
+// takes the place of the token at the same level, and is only run when it is used.
+func (s credentialSettings) resolve(ctx context.Context) (string, string, error) {
+	token, tokenCommand, endpoint := s.APIToken, s.APITokenCommand, s.Endpoint
+
+	apply := func(p credentialsProfile) {
+		if token == "" && tokenCommand == "" {
+			token, tokenCommand = p.APIToken, p.APITokenCommand
+		}
+
+		if endpoint == "" {
+			endpoint = p.Endpoint
+		}
+	}
+
+	profile := s.Profile
+
+	if profile == "" {
+		profile = os.Getenv("HAVA_PROFILE")
+	}
+
+	path := s.ConfigFile
+
+	if path == "" {
+		var err error
+
+		if path, err = defaultCredentialsFile(); err != nil && profile != "" {
+			return "", "", err
+		}
+	}
+
+	if profile != "" {
+		p, err := readCredentialsProfile(path, profile)
+
+		if err != nil {
+			return "", "", err
+		}
+
+		apply(*p)
+	}
+
+	apply(credentialsProfile{
+		APIToken: os.Getenv("HAVA_TOKEN"),
+		Endpoint: os.Getenv("HAVA_ENDPOINT"),
+	})
+
+	if profile == "" && path != "" {
+		p, err := readCredentialsProfile(path, defaultProfile)
+
+		switch {
+		case err == nil:
+			apply(*p)
+		case errors.Is(err, fs.ErrNotExist) && s.ConfigFile == "":
+			// the credentials file is optional unless configured explicitly
+		case errors.Is(err, errProfileNotFound):
+			// the file only holds named profiles
+		default:
+			return "", "", err
+		}
+	}
+
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+
+	if token == "" && tokenCommand != "" {
+		var err error
+
+		if token, err = runAPITokenCommand(ctx, tokenCommand); err != nil {
+			return "", "", err
+		}
+	}
+
+	return token, endpoint, nil
+}
+
+var (
+	apiTokensMu sync.Mutex
+	apiTokens   = map[string]string{}
+)
+
+// runAPITokenCommand runs the command and returns the token it prints. Tokens are cached for
+// the life of the provider process, so the command runs once even though the SDKv2 and
+// framework providers are configured separately.
+func runAPITokenCommand(ctx context.Context, command string) (string, error) {
+	apiTokensMu.Lock()
+	defer apiTokensMu.Unlock()
+
+	if token, ok := apiTokens[command]; ok {
+		return token, nil
+	}
+
+	var cmd *exec.Cmd
+
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stderr bytes.Buffer
+
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+
+	if err != nil {
+		return "", fmt.Errorf("the api token command failed: %w\n\n%s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	token := strings.TrimSpace(string(out))
+
+	if token == "" {
+		return "", fmt.Errorf("the api token command didn't print a token")
+	}
+
+	apiTokens[command] = token
+
+	return token, nil
+}
+
+// defaultCredentialsFile returns the path of the credentials file used when config_file isn't
+// set
+func defaultCredentialsFile() (string, error) {
+	home, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", fmt.Errorf("unable to find the credentials file: %w", err)
+	}
+
+	return filepath.Join(home, ".hava", "credentials"), nil
+}
+
+var errProfileNotFound = errors.New("profile not found")
+
+// readCredentialsProfile reads the named profile from the credentials file at path
+func readCredentialsProfile(path string, name string) (*credentialsProfile, error) {
+	profiles, err := readCredentialsFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := profiles[name]
+
+	if !ok {
+		return nil, fmt.Errorf("%w: the credentials file %s has no profile '%s'", errProfileNotFound, path, name)
+	}
+
+	return p, nil
+}
+
+// readCredentialsFile parses a credentials file in INI format, such as
+//
+//	[default]
+//	api_token = ...
+//
+//	[self-hosted]
+//	api_token_command = vault kv get -field=token secret/hava
+//	endpoint          = https://hava.example.com
+//
+// Unknown keys are ignored. Errors never include values from the file, as it holds secrets.
+func readCredentialsFile(path string) (map[string]*credentialsProfile, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the credentials file: %w", err)
+	}
+
+	defer f.Close()
+
+	profiles := map[string]*credentialsProfile{}
+
+	var current *credentialsProfile
+
+	scanner := bufio.NewScanner(f)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+
+			if _, ok := profiles[name]; !ok {
+				profiles[name] = &credentialsProfile{}
+			}
+
+			current = profiles[name]
+
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+
+		if !ok || current == nil {
+			return nil, fmt.Errorf("unable to parse the credentials file %s: line %d is not a [profile] header or a key = value pair in a profile", path, n)
+		}
+
+		switch strings.TrimSpace(key) {
+		case "api_token":
+			current.APIToken = strings.TrimSpace(value)
+		case "api_token_command":
+			current.APITokenCommand = strings.TrimSpace(value)
+		case "endpoint":
+			current.Endpoint = strings.TrimSpace(value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read the credentials file: %w", err)
+	}
+
+	return profiles, nil
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCredentialSettingsResolve(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "credentials")
+
+	err := os.WriteFile(path, []byte(`# Hava credentials
+[default]
+api_token = default-token
+
+[self-hosted]
+api_token = self-hosted-token
+endpoint  = https://hava.example.com
+
+[endpoint-only]
+endpoint = https://other.example.com
+
+[command]
+api_token_command = echo profile-command-token
+`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		settings     credentialSettings
+		env          map[string]string
+		wantToken    string
+		wantEndpoint string
+		wantErr      bool
+	}{
+		"default profile": {
+			settings:     credentialSettings{ConfigFile: path},
+			wantToken:    "default-token",
+			wantEndpoint: defaultEndpoint,
+		},
+		"attributes take precedence": {
+			settings:     credentialSettings{APIToken: "attribute-token", Endpoint: "https://attribute.example.com", Profile: "self-hosted", ConfigFile: path},
+			env:          map[string]string{"HAVA_TOKEN": "env-token"},
+			wantToken:    "attribute-token",
+			wantEndpoint: "https://attribute.example.com",
+		},
+		"profile attribute": {
+			settings:     credentialSettings{Profile: "self-hosted", ConfigFile: path},
+			env:          map[string]string{"HAVA_TOKEN": "env-token", "HAVA_PROFILE": "default"},
+			wantToken:    "self-hosted-token",
+			wantEndpoint: "https://hava.example.com",
+		},
+		"profile environment variable": {
+			settings:     credentialSettings{ConfigFile: path},
+			env:          map[string]string{"HAVA_PROFILE": "self-hosted", "HAVA_ENDPOINT": "https://env.example.com"},
+			wantToken:    "self-hosted-token",
+			wantEndpoint: "https://hava.example.com",
+		},
+		"environment variables take precedence over the default profile": {
+			settings:     credentialSettings{ConfigFile: path},
+			env:          map[string]string{"HAVA_TOKEN": "env-token", "HAVA_ENDPOINT": "https://env.example.com"},
+			wantToken:    "env-token",
+			wantEndpoint: "https://env.example.com",
+		},
+		"values are resolved on their own": {
+			settings:     credentialSettings{Profile: "endpoint-only", ConfigFile: path},
+			env:          map[string]string{"HAVA_TOKEN": "env-token"},
+			wantToken:    "env-token",
+			wantEndpoint: "https://other.example.com",
+		},
+		"missing default credentials file": {
+			settings:     credentialSettings{},
+			env:          map[string]string{"HOME": dir, "HAVA_TOKEN": "env-token"},
+			wantToken:    "env-token",
+			wantEndpoint: defaultEndpoint,
+		},
+		"token command": {
+			settings:     credentialSettings{APITokenCommand: "echo command-token", ConfigFile: path},
+			env:          map[string]string{"HAVA_TOKEN": "env-token"},
+			wantToken:    "command-token",
+			wantEndpoint: defaultEndpoint,
+		},
+		"token attribute takes precedence over the token command": {
+			settings:     credentialSettings{APIToken: "attribute-token", APITokenCommand: "exit 1", ConfigFile: path},
+			wantToken:    "attribute-token",
+			wantEndpoint: defaultEndpoint,
+		},
+		"profile token command": {
+			settings:     credentialSettings{Profile: "command", ConfigFile: path},
+			env:          map[string]string{"HAVA_TOKEN": "env-token"},
+			wantToken:    "profile-command-token",
+			wantEndpoint: defaultEndpoint,
+		},
+		"failing token command": {
+			settings: credentialSettings{APITokenCommand: "echo denied >&2; exit 1", ConfigFile: path},
+			wantErr:  true,
+		},
+		"token command without output": {
+			settings: credentialSettings{APITokenCommand: "true", ConfigFile: path},
+			wantErr:  true,
+		},
+		"missing profile": {
+			settings: credentialSettings{Profile: "missing", ConfigFile: path},
+			wantErr:  true,
+		},
+		"missing configured credentials file": {
+			settings: credentialSettings{ConfigFile: filepath.Join(dir, "missing")},
+			wantErr:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			for _, k := range []string{"HAVA_TOKEN", "HAVA_ENDPOINT", "HAVA_PROFILE"} {
+				t.Setenv(k, "")
+			}
+
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			token, endpoint, err := tc.settings.resolve(context.Background())
+
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if token != tc.wantToken {
+				t.Errorf("expected token %q, got %q", tc.wantToken, token)
+			}
+
+			if endpoint != tc.wantEndpoint {
+				t.Errorf("expected endpoint %q, got %q", tc.wantEndpoint, endpoint)
+			}
+		})
+	}
+}
+
+func TestRunAPITokenCommand_cached(t *testing.T) {
+	// count the runs in a file, as the command runs in another process
+	count := filepath.Join(t.TempDir(), "count")
+	command := "echo run >> " + count + "; echo cached-token"
+
+	for range 2 {
+		token, err := runAPITokenCommand(context.Background(), command)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if token != "cached-token" {
+			t.Errorf("expected token %q, got %q", "cached-token", token)
+		}
+	}
+
+	runs, err := os.ReadFile(count)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := strings.Count(string(runs), "run"); n != 1 {
+		t.Errorf("expected the command to run once, ran %d times", n)
+	}
+}
+
+func TestReadCredentialsFile_invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+
+	if err := os.WriteFile(path, []byte("api_token = secret-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := readCredentialsFile(path)
+
+	if err == nil {
+		t.Fatal("expected an error for a key outside of a profile")
+	}
+
+	// the credentials file holds secrets, so they must not be echoed back
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("expected the error not to include values from the file, got %q", err)
+	}
+}
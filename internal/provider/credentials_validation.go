@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	havaclient "github.com/teamhava/hava-sdk-go"
+)
+
+// skipCredentialsValidationDescription is the description of the skip_credentials_validation
+// provider attribute, shared by the SDKv2 and framework provider schemas
+const skipCredentialsValidationDescription = "Skip checking the API token and endpoint with a request to the Hava API when the provider is configured. Defaults to `false`."
+
+// credentialsValidationError explains why the API token or endpoint can't be used, with a
+// summary telling a bad token, a wrong endpoint and a network failure apart
+type credentialsValidationError struct {
+	Summary string
+	Detail  string
+}
+
+func (e *credentialsValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Summary, e.Detail)
+}
+
+var (
+	credentialValidationsMu sync.Mutex
+	credentialValidations   = map[providerConfig]*credentialsValidationError{}
+)
+
+// validateCredentials checks the API token and endpoint of the configuration by listing a
+// single source, which any token that can manage sources is allowed to do. The result is
+// cached, so the API is called once even though the SDKv2 and framework providers are
+// configured separately.
+func (c providerConfig) validateCredentials(ctx context.Context, client *havaclient.APIClient) *credentialsValidationError {
+	credentialValidationsMu.Lock()
+	defer credentialValidationsMu.Unlock()
+
+	if result, ok := credentialValidations[c]; ok {
+		return result
+	}
+
+	tflog.Debug(ctx, "validating the API token and endpoint", map[string]any{"endpoint": c.Endpoint})
+
+	_, res, err := client.SourcesApi.SourcesIndex(ctx).PageSize(1).Execute()
+
+	result := c.credentialsValidationResult(res, err)
+
+	credentialValidations[c] = result
+
+	return result
+}
+
+func (c providerConfig) credentialsValidationResult(res *http.Response, err error) *credentialsValidationError {
+	const skip = "\n\nSet skip_credentials_validation to skip this check."
+
+	switch {
+	case err == nil:
+		return nil
+	case res == nil:
+		return &credentialsValidationError{
+			Summary: "Unable to connect to the Hava API",
+			Detail:  fmt.Sprintf("The Hava API at %s could not be reached, check the network connection, proxy and TLS settings: %s.%s", c.Endpoint, err, skip),
+		}
+	case res.StatusCode == http.StatusUnauthorized:
+		return &credentialsValidationError{
+			Summary: "Invalid Hava API token",
+			Detail:  fmt.Sprintf("The Hava API at %s rejected the API token, it may have been revoked or belong to another Hava install. Check api_token, api_token_command, the HAVA_TOKEN environment variable or the selected profile.", c.Endpoint),
+		}
+	case res.StatusCode == http.StatusForbidden:
+		return &credentialsValidationError{
+			Summary: "Insufficient Hava API token permissions",
+			Detail:  fmt.Sprintf("The Hava API at %s accepted the API token, but it isn't allowed to list sources. Check the permissions of the token in Hava.%s", c.Endpoint, skip),
+		}
+	case res.StatusCode == http.StatusNotFound, res.StatusCode < 300:
+		// a successful response that isn't a list of sources comes from something else than
+		// the Hava API, e.g. the web interface of a self-hosted install
+		return &credentialsValidationError{
+			Summary: "Invalid Hava API endpoint",
+			Detail:  fmt.Sprintf("%s doesn't look like the Hava API, it responded with %s to a request to list sources. Check the endpoint attribute, the HAVA_ENDPOINT environment variable or the selected profile.%s", c.Endpoint, res.Status, skip),
+		}
+	default:
+		return &credentialsValidationError{
+			Summary: "Unable to connect to the Hava API",
+			Detail:  fmt.Sprintf("The Hava API at %s responded with %s.%s", c.Endpoint, res.Status, skip),
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateCredentials(t *testing.T) {
+	cases := map[string]struct {
+		handler     http.HandlerFunc
+		closed      bool
+		wantSummary string
+	}{
+		"valid": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"results":[]}`)
+			},
+		},
+		"bad token": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			wantSummary: "Invalid Hava API token",
+		},
+		"not found": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			wantSummary: "Invalid Hava API endpoint",
+		},
+		"web page": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				fmt.Fprint(w, `<html><body>Hava</body></html>`)
+			},
+			wantSummary: "Invalid Hava API endpoint",
+		},
+		"server error": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantSummary: "Unable to connect to the Hava API",
+		},
+		"network failure": {
+			handler:     func(w http.ResponseWriter, r *http.Request) {},
+			closed:      true,
+			wantSummary: "Unable to connect to the Hava API",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+
+				if r.Header.Get("Authorization") != "Bearer "+name {
+					t.Errorf("expected the request to be authenticated with the API token")
+				}
+
+				tc.handler(w, r)
+			}))
+			t.Cleanup(server.Close)
+
+			if tc.closed {
+				server.Close()
+			}
+
+			config := providerConfig{APIToken: name, Endpoint: server.URL}
+
+			client, err := config.client(context.Background(), "test")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			for range 2 {
+				err := config.validateCredentials(context.Background(), client)
+
+				if tc.wantSummary == "" {
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+
+					continue
+				}
+
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				if err.Summary != tc.wantSummary {
+					t.Errorf("expected summary %q, got %q", tc.wantSummary, err.Summary)
+				}
+
+				if !strings.Contains(err.Detail, server.URL) {
+					t.Errorf("expected the detail to include the endpoint, got %q", err.Detail)
+				}
+			}
+
+			// the result is cached, as the SDKv2 and framework providers both validate
+			if !tc.closed && calls != 1 {
+				t.Errorf("expected 1 request, got %d", calls)
+			}
+		})
+	}
+}
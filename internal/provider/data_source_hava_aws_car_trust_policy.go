@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// havaPrincipal is the AWS account Hava assumes cross account roles from
+const havaPrincipal = "arn:aws:iam::281013829959:root"
+
+// awsPartitions are the AWS partitions cross account roles can be created in
+var awsPartitions = []string{"aws", "aws-us-gov", "aws-cn"}
+
+// havaManagedPolicies are the AWS managed policies Hava recommends attaching to the cross
+// account role, giving it read-only access to the account
+var havaManagedPolicies = []string{"ReadOnlyAccess"}
+
+var _ datasource.DataSource = &awsCARTrustPolicyDataSource{}
+
+func NewAWSCARTrustPolicyDataSource() datasource.DataSource {
+	return &awsCARTrustPolicyDataSource{}
+}
+
+// awsCARTrustPolicyDataSource renders the trust policy of the IAM role Hava assumes to import an
+// AWS account. The Hava API doesn't expose the account's external ID, or the principal of a
+// self-hosted Hava, so both are configured, with the principal defaulting to Hava's SaaS
+// account.
+type awsCARTrustPolicyDataSource struct{}
+
+type awsCARTrustPolicyDataSourceModel struct {
+	ID                     types.String `tfsdk:"id"`
+	ExternalID             types.String `tfsdk:"external_id"`
+	Principal              types.String `tfsdk:"principal"`
+	Partition              types.String `tfsdk:"partition"`
+	IncludeManagedPolicies types.Bool   `tfsdk:"include_managed_policies"`
+	JSON                   types.String `tfsdk:"json"`
+	ManagedPolicyARNs      []string     `tfsdk:"managed_policy_arns"`
+}
+
+func (d *awsCARTrustPolicyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_aws_car_trust_policy"
+}
+
+func (d *awsCARTrustPolicyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Renders the trust policy of the IAM role that Hava assumes to import an AWS account, ready to use as the `assume_role_policy` of an `aws_iam_role`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this data source.",
+				Computed:            true,
+			},
+			"external_id": schema.StringAttribute{
+				MarkdownDescription: "The external ID of the Hava account, shown in the 'Add Environment' prompt in Hava. The Hava API doesn't expose it, so it has to be configured.",
+				Required:            true,
+			},
+			"principal": schema.StringAttribute{
+				MarkdownDescription: "ARN of the AWS principal Hava assumes the role from. Self-hosted Hava installations use their own AWS account. Defaults to `" + havaPrincipal + "`, the account of the Hava SaaS, which is in the `aws` partition, so it is required when `partition` is `aws-us-gov` or `aws-cn`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"partition": schema.StringAttribute{
+				MarkdownDescription: "AWS partition the role is created in, one of `aws`, `aws-us-gov` or `aws-cn`. Used for the ARNs of the managed policies. Defaults to `aws`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(awsPartitions...),
+				},
+			},
+			"include_managed_policies": schema.BoolAttribute{
+				MarkdownDescription: "Set `managed_policy_arns` to the AWS managed policies Hava recommends attaching to the role. Defaults to `false`.",
+				Optional:            true,
+			},
+			"json": schema.StringAttribute{
+				MarkdownDescription: "The trust policy as JSON",
+				Computed:            true,
+			},
+			"managed_policy_arns": schema.ListAttribute{
+				MarkdownDescription: "ARNs of the AWS managed policies Hava recommends attaching to the role, giving it read-only access to the account. Empty unless `include_managed_policies` is set.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *awsCARTrustPolicyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data awsCARTrustPolicyDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Partition.IsNull() {
+		data.Partition = types.StringValue("aws")
+	}
+
+	if data.Principal.IsNull() {
+		// Hava's SaaS account is in the commercial partition, and can't assume roles in the
+		// isolated GovCloud and China partitions
+		if data.Partition.ValueString() != "aws" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("principal"),
+				"Missing principal",
+				fmt.Sprintf("The default principal, %s, is in the aws partition and can't assume roles in the %s partition. Set principal to the AWS account of the Hava installation in that partition.", havaPrincipal, data.Partition.ValueString()),
+			)
+
+			return
+		}
+
+		data.Principal = types.StringValue(havaPrincipal)
+	}
+
+	policy, err := awsCARTrustPolicy(data.Principal.ValueString(), data.ExternalID.ValueString())
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to render trust policy", err.Error())
+		return
+	}
+
+	data.JSON = types.StringValue(policy)
+	data.ID = types.StringValue(strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(policy))), 10))
+
+	data.ManagedPolicyARNs = make([]string, 0, len(havaManagedPolicies))
+
+	if data.IncludeManagedPolicies.ValueBool() {
+		for _, name := range havaManagedPolicies {
+			data.ManagedPolicyARNs = append(data.ManagedPolicyARNs, fmt.Sprintf("arn:%s:iam::aws:policy/%s", data.Partition.ValueString(), name))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// awsCARTrustPolicy returns the trust policy allowing principal to assume a role with the
+// given external ID
+func awsCARTrustPolicy(principal string, externalID string) (string, error) {
+	policy := map[string]any{
+		"Version": "2012-10-17",
+		"Statement": []any{
+			map[string]any{
+				"Effect": "Allow",
+				"Principal": map[string]any{
+					"AWS": principal,
+				},
+				"Action": "sts:AssumeRole",
+				"Condition": map[string]any{
+					"StringEquals": map[string]any{
+						"sts:ExternalId": externalID,
+					},
+				},
+			},
+		},
+	}
+
+	b, err := json.MarshalIndent(policy, "", "  ")
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAWSCARTrustPolicy(t *testing.T) {
+	policy, err := awsCARTrustPolicy(havaPrincipal, "0123456789abcdef")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got map[string]any
+
+	if err := json.Unmarshal([]byte(policy), &got); err != nil {
+		t.Fatalf("expected the policy to be JSON: %s", err)
+	}
+
+	want := map[string]any{
+		"Version": "2012-10-17",
+		"Statement": []any{
+			map[string]any{
+				"Effect":    "Allow",
+				"Principal": map[string]any{"AWS": "arn:aws:iam::281013829959:root"},
+				"Action":    "sts:AssumeRole",
+				"Condition": map[string]any{
+					"StringEquals": map[string]any{"sts:ExternalId": "0123456789abcdef"},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected policy %s", policy)
+	}
+}
+
+func TestAWSCARTrustPolicyDataSource_read(t *testing.T) {
+	cases := map[string]struct {
+		principal              *string
+		partition              *string
+		includeManagedPolicies *bool
+		wantPrincipal          string
+		wantARNs               []string
+		wantError              bool
+	}{
+		"defaults": {
+			wantPrincipal: havaPrincipal,
+		},
+		"managed policies": {
+			includeManagedPolicies: boolPointer(true),
+			wantPrincipal:          havaPrincipal,
+			wantARNs:               []string{"arn:aws:iam::aws:policy/ReadOnlyAccess"},
+		},
+		"GovCloud": {
+			principal:              stringPointer("arn:aws-us-gov:iam::123456789012:root"),
+			partition:              stringPointer("aws-us-gov"),
+			includeManagedPolicies: boolPointer(true),
+			wantPrincipal:          "arn:aws-us-gov:iam::123456789012:root",
+			wantARNs:               []string{"arn:aws-us-gov:iam::aws:policy/ReadOnlyAccess"},
+		},
+		"China": {
+			principal:              stringPointer("arn:aws-cn:iam::123456789012:root"),
+			partition:              stringPointer("aws-cn"),
+			includeManagedPolicies: boolPointer(true),
+			wantPrincipal:          "arn:aws-cn:iam::123456789012:root",
+			wantARNs:               []string{"arn:aws-cn:iam::aws:policy/ReadOnlyAccess"},
+		},
+		"China without a principal": {
+			partition: stringPointer("aws-cn"),
+			wantError: true,
+		},
+	}
+
+	ctx := context.Background()
+
+	ds := NewAWSCARTrustPolicyDataSource()
+
+	var schemaResp datasource.SchemaResponse
+
+	ds.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"id":                       tftypes.NewValue(tftypes.String, nil),
+				"external_id":              tftypes.NewValue(tftypes.String, "0123456789abcdef"),
+				"principal":                tftypes.NewValue(tftypes.String, tc.principal),
+				"partition":                tftypes.NewValue(tftypes.String, tc.partition),
+				"include_managed_policies": tftypes.NewValue(tftypes.Bool, tc.includeManagedPolicies),
+				"json":                     tftypes.NewValue(tftypes.String, nil),
+				"managed_policy_arns":      tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+			})
+
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+			}
+
+			resp := datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+
+			ds.Read(ctx, req, &resp)
+
+			if tc.wantError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected an error")
+				}
+
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %+v", resp.Diagnostics)
+			}
+
+			var got awsCARTrustPolicyDataSourceModel
+
+			resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %+v", resp.Diagnostics)
+			}
+
+			if got.Principal.ValueString() != tc.wantPrincipal {
+				t.Errorf("expected principal %q, got %q", tc.wantPrincipal, got.Principal.ValueString())
+			}
+
+			if !strings.Contains(got.JSON.ValueString(), tc.wantPrincipal) {
+				t.Errorf("expected the policy to trust %q, got %s", tc.wantPrincipal, got.JSON.ValueString())
+			}
+
+			if _, err := strconv.ParseUint(got.ID.ValueString(), 10, 32); err != nil {
+				t.Errorf("expected the ID to be the unsigned checksum of the policy, got %q", got.ID.ValueString())
+			}
+
+			if !slices.Equal(got.ManagedPolicyARNs, tc.wantARNs) {
+				t.Errorf("expected managed policy ARNs %v, got %v", tc.wantARNs, got.ManagedPolicyARNs)
+			}
+		})
+	}
+}
+
+func stringPointer(s string) *string {
+	return &s
+}
+
+func boolPointer(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	havaclient "github.com/teamhava/hava-sdk-go"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure        = &sourceDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &sourceDataSource{}
+)
+
+func NewSourceDataSource() datasource.DataSource {
+	return &sourceDataSource{}
+}
+
+type sourceDataSource struct {
+	client *havaclient.APIClient
+}
+
+type sourceDataSourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	Name  types.String `tfsdk:"name"`
+	Type  types.String `tfsdk:"type"`
+	State types.String `tfsdk:"state"`
+}
+
+func (d *sourceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source"
+}
+
+func (d *sourceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Looks up a single Source in Hava by its ID or its exact name.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the source, either this or `name` must be set",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Exact display name of the source, or its name if it has none, either this or `id` must be set",
+				Optional:            true,
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the Source",
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the Source",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *sourceDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (d *sourceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*havaclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *havaclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *sourceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "reading")
+
+	var data sourceDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+
+	if id == "" {
+		var err error
+		id, err = findSourceIDByName(ctx, d.client, data.Name.ValueString())
+
+		var apiErr *apiError
+
+		if errors.As(err, &apiErr) {
+			resp.Diagnostics.Append(frameworkAPIErrorDiagnostics("Unable to find source", err)...)
+			return
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Unable to find source", err.Error())
+			return
+		}
+	}
+
+	source, res, err := d.client.SourcesApi.SourcesShow(ctx, id).Execute()
+
+	if err != nil {
+		resp.Diagnostics.Append(frameworkAPIErrorDiagnostics(fmt.Sprintf("Unable to read source '%s'", id), newAPIError(res, err))...)
+		return
+	}
+
+	data.ID = types.StringValue(source.GetId())
+	data.Name = types.StringValue(sourceDisplayName(*source))
+	data.Type = types.StringValue(source.GetType())
+	data.State = types.StringValue(source.GetState())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findSourceIDByName returns the ID of the only source with the given display name
+func findSourceIDByName(ctx context.Context, client *havaclient.APIClient, name string) (string, error) {
+	sources, err := listSources(ctx, client)
+
+	if err != nil {
+		return "", err
+	}
+
+	var ids []string
+
+	for _, source := range sources {
+		if sourceDisplayName(source) == name {
+			ids = append(ids, source.GetId())
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no source named '%s' was found", name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("found %d sources named '%s' (%s), use the id attribute to select one of them", len(ids), name, strings.Join(ids, ", "))
+	}
+}
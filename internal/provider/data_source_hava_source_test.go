@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestFindSourceIDByName(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"results":[
+			{"id":"1","name":"shared","type":"AWS::Keys"},
+			{"id":"2","name":"duplicate","type":"AWS::Keys"},
+			{"id":"3","name":"duplicate","type":"AWS::CrossAccountRole"},
+			{"id":"4","name":"original","display_name":"renamed","type":"AWS::Keys"}
+		]}`)
+	}))
+
+	id, err := findSourceIDByName(context.Background(), client, "shared")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if id != "1" {
+		t.Errorf("expected id 1, got %q", id)
+	}
+
+	id, err = findSourceIDByName(context.Background(), client, "renamed")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if id != "4" {
+		t.Errorf("expected the display name to match source 4, got %q", id)
+	}
+
+	if _, err := findSourceIDByName(context.Background(), client, "original"); err == nil {
+		t.Error("expected no match on the name of a source that has a display name")
+	}
+
+	if _, err := findSourceIDByName(context.Background(), client, "missing"); err == nil {
+		t.Error("expected an error when no source matches the name")
+	}
+
+	if _, err := findSourceIDByName(context.Background(), client, "duplicate"); err == nil {
+		t.Error("expected an error when several sources match the name")
+	}
+}
@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	havaclient "github.com/teamhava/hava-sdk-go"
+)
+
+var _ datasource.DataSourceWithConfigure = &sourcesDataSource{}
+
+func NewSourcesDataSource() datasource.DataSource {
+	return &sourcesDataSource{}
+}
+
+type sourcesDataSource struct {
+	client *havaclient.APIClient
+}
+
+type sourcesDataSourceModel struct {
+	ID        types.String            `tfsdk:"id"`
+	Type      types.String            `tfsdk:"type"`
+	State     types.String            `tfsdk:"state"`
+	NameRegex types.String            `tfsdk:"name_regex"`
+	IDs       []string                `tfsdk:"ids"`
+	Sources   []sourcesDataSourceItem `tfsdk:"sources"`
+}
+
+type sourcesDataSourceItem struct {
+	ID    types.String `tfsdk:"id"`
+	Name  types.String `tfsdk:"name"`
+	Type  types.String `tfsdk:"type"`
+	State types.String `tfsdk:"state"`
+}
+
+func (d *sourcesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sources"
+}
+
+func (d *sourcesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the Sources in Hava, optionally filtered by type, state and name.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this data source.",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only return sources of this type, one of `AWS::CrossAccountRole`, `AWS::Keys`, `Azure::Credentials` or `GCP::ServiceAccountCredentials`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(sourceTypes...),
+				},
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "Only return sources in this state, e.g. `active`",
+				Optional:            true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return sources with a display name, or a name if they have none, matching this regular expression",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching sources",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"sources": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching sources",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "ID of the source",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Display name of the source, or its name if it has none",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Type of the source",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "State of the source",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *sourcesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*havaclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *havaclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *sourcesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "reading")
+
+	var data sourcesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameMatcher *regexp.Regexp
+
+	if nameRegex := data.NameRegex.ValueString(); nameRegex != "" {
+		var err error
+		nameMatcher, err = regexp.Compile(nameRegex)
+
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+			return
+		}
+	}
+
+	sources, err := listSources(ctx, d.client)
+
+	if err != nil {
+		resp.Diagnostics.Append(frameworkAPIErrorDiagnostics("Unable to list sources", err)...)
+		return
+	}
+
+	matches := filterSources(sources, data.Type.ValueString(), data.State.ValueString(), nameMatcher)
+
+	tflog.Debug(ctx, fmt.Sprintf("found %d matching sources out of %d", len(matches), len(sources)))
+
+	data.IDs = []string{}
+	data.Sources = []sourcesDataSourceItem{}
+
+	for _, source := range matches {
+		data.IDs = append(data.IDs, source.GetId())
+		data.Sources = append(data.Sources, sourcesDataSourceItem{
+			ID:    types.StringValue(source.GetId()),
+			Name:  types.StringValue(sourceDisplayName(source)),
+			Type:  types.StringValue(source.GetType()),
+			State: types.StringValue(source.GetState()),
+		})
+	}
+
+	filters := data.Type.ValueString() + "/" + data.State.ValueString() + "/" + data.NameRegex.ValueString()
+	data.ID = types.StringValue(strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(filters))), 10))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterSources returns the sources matching all of the filters, empty filters match any source
+func filterSources(sources []havaclient.Source, sourceType string, state string, name *regexp.Regexp) []havaclient.Source {
+	var matches []havaclient.Source
+
+	for _, source := range sources {
+		if sourceType != "" && source.GetType() != sourceType {
+			continue
+		}
+
+		if state != "" && source.GetState() != state {
+			continue
+		}
+
+		if name != nil && !name.MatchString(sourceDisplayName(source)) {
+			continue
+		}
+
+		matches = append(matches, source)
+	}
+
+	return matches
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestFilterSources(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		// serve the sources over two pages to exercise pagination
+		if r.URL.Query().Get("token") == "" {
+			fmt.Fprint(w, `{"next_page_token":"page2","results":[
+				{"id":"1","name":"prod-car","type":"AWS::CrossAccountRole","state":"active"},
+				{"id":"2","name":"prod-keys","type":"AWS::Keys","state":"active"}
+			]}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"results":[
+			{"id":"3","name":"dev-car","type":"AWS::CrossAccountRole","state":"active"},
+			{"id":"4","name":"prod-car-broken","type":"AWS::CrossAccountRole","state":"error"},
+			{"id":"5","name":"prod-car-renamed","display_name":"staging-car","type":"AWS::CrossAccountRole","state":"active"}
+		]}`)
+	}))
+
+	sources, err := listSources(context.Background(), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(sources) != 5 {
+		t.Fatalf("expected 5 sources across both pages, got %d", len(sources))
+	}
+
+	matches := filterSources(sources, sourceTypeAWSCAR, "active", regexp.MustCompile("^prod-"))
+
+	if len(matches) != 1 || matches[0].GetId() != "1" {
+		t.Fatalf("expected only source 1 to match, got %+v", matches)
+	}
+
+	if matches := filterSources(sources, "", "", regexp.MustCompile("^staging-")); len(matches) != 1 || matches[0].GetId() != "5" {
+		t.Errorf("expected the display name of source 5 to match, got %+v", matches)
+	}
+
+	if all := filterSources(sources, "", "", nil); len(all) != len(sources) {
+		t.Errorf("expected empty filters to match all %d sources, got %d", len(sources), len(all))
+	}
+}
@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	havaclient "github.com/teamhava/hava-sdk-go"
+)
+
+// maxErrorBodyLength is how much of a response body that isn't a list of Hava errors is
+// included in a diagnostic
+const maxErrorBodyLength = 512
+
+// apiError is an error returned by the Hava API, together with the status code of the
+// response, which the errors returned by the SDK don't include
+type apiError struct {
+	StatusCode int
+	Status     string
+	Err        error
+}
+
+// newAPIError wraps err, as returned by the SDK for the response res, in an apiError. Errors
+// without a response, e.g. because the API couldn't be reached, are returned as is.
+func newAPIError(res *http.Response, err error) error {
+	if err == nil || res == nil {
+		return err
+	}
+
+	return &apiError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		Err:        err,
+	}
+}
+
+func (e *apiError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *apiError) Unwrap() error {
+	return e.Err
+}
+
+// details returns the errors Hava reported in the response body, if any
+func (e *apiError) details() ([]havaclient.ErrorInner, string) {
+	var openAPIErr *havaclient.GenericOpenAPIError
+
+	if !errors.As(e.Err, &openAPIErr) {
+		return nil, ""
+	}
+
+	if details, ok := openAPIErr.Model().([]havaclient.ErrorInner); ok && len(details) > 0 {
+		return details, ""
+	}
+
+	body := strings.TrimSpace(string(openAPIErr.Body()))
+
+	var details []havaclient.ErrorInner
+
+	if err := json.Unmarshal([]byte(body), &details); err == nil && len(details) > 0 {
+		return details, ""
+	}
+
+	if len(body) > maxErrorBodyLength {
+		body = body[:maxErrorBodyLength] + "..."
+	}
+
+	return nil, body
+}
+
+// apiErrorDiagnostics returns the diagnostics for an error from the Hava API. The summary
+// says what failed, e.g. "Unable to create source 'example'", and the detail has the HTTP
+// status and the errors Hava reported. Errors about a field are attached to the attribute
+// returned by attributePath, if it returns one.
+func apiErrorDiagnostics(summary string, err error, attributePath func(field string) cty.Path) diag.Diagnostics {
+	var apiErr *apiError
+
+	if !errors.As(err, &apiErr) {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  summary,
+				Detail:   err.Error(),
+			},
+		}
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusUnauthorized:
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  summary,
+				Detail:   fmt.Sprintf("The Hava API rejected the API token (%s). Check that the API token the provider resolves holds a valid API token that has not been revoked: the api_token or api_token_command provider attribute, the HAVA_TOKEN environment variable, or the profile selected with profile or HAVA_PROFILE in the credentials file. Also check that the endpoint is the Hava API the token was created for.", apiErr.Status),
+			},
+		}
+	case http.StatusForbidden:
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  summary,
+				Detail:   fmt.Sprintf("The Hava API refused the request (%s). Check that the API token belongs to a user who is allowed to manage sources in the Hava account, and that the account's plan allows another source of this type; sources beyond the plan limits are refused until the plan is upgraded.", apiErr.Status),
+			},
+		}
+	}
+
+	details, body := apiErr.details()
+
+	if len(details) == 0 {
+		detail := fmt.Sprintf("The Hava API returned %s.", apiErr.Status)
+
+		if body != "" {
+			detail += "\n\n" + body
+		}
+
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  summary,
+				Detail:   detail,
+			},
+		}
+	}
+
+	diags := make(diag.Diagnostics, 0, len(details))
+
+	for _, detail := range details {
+		d := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   fmt.Sprintf("The Hava API returned %s", apiErr.Status),
+		}
+
+		if title := detail.GetTitle(); title != "" {
+			d.Summary = fmt.Sprintf("%s: %s", summary, title)
+		}
+
+		if detail.GetDetail() != "" {
+			d.Detail += ": " + detail.GetDetail()
+		}
+
+		if detail.GetPath() != "" && attributePath != nil {
+			d.AttributePath = attributePath(detail.GetPath())
+		}
+
+		diags = append(diags, d)
+	}
+
+	return diags
+}
+
+// apiErrorAsError returns the diagnostics of apiErrorDiagnostics as a single error, for code
+// paths that can only return errors, such as importers
+func apiErrorAsError(summary string, err error) error {
+	diags := apiErrorDiagnostics(summary, err, nil)
+
+	messages := make([]string, 0, len(diags))
+
+	for _, d := range diags {
+		messages = append(messages, fmt.Sprintf("%s: %s", d.Summary, d.Detail))
+	}
+
+	return &diagnosticsError{
+		message: strings.Join(messages, "\n\n"),
+		err:     err,
+	}
+}
+
+// diagnosticsError is an error described by diagnostics, that still unwraps to the error the
+// diagnostics were created from
+type diagnosticsError struct {
+	message string
+	err     error
+}
+
+func (e *diagnosticsError) Error() string {
+	return e.message
+}
+
+func (e *diagnosticsError) Unwrap() error {
+	return e.err
+}
+
+// frameworkAPIErrorDiagnostics returns the diagnostics of apiErrorDiagnostics for the data
+// sources and resources served by the framework provider
+func frameworkAPIErrorDiagnostics(summary string, err error) fwdiag.Diagnostics {
+	return frameworkDiagnostics(apiErrorDiagnostics(summary, err, nil))
+}
+
+// frameworkDiagnostics converts SDKv2 diagnostics, such as those of apiErrorDiagnostics, to
+// framework diagnostics with the same severity and attribute path
+func frameworkDiagnostics(diags diag.Diagnostics) fwdiag.Diagnostics {
+	var converted fwdiag.Diagnostics
+
+	for _, d := range diags {
+		switch {
+		case d.Severity == diag.Warning && len(d.AttributePath) > 0:
+			converted.AddAttributeWarning(frameworkPath(d.AttributePath), d.Summary, d.Detail)
+		case d.Severity == diag.Warning:
+			converted.AddWarning(d.Summary, d.Detail)
+		case len(d.AttributePath) > 0:
+			converted.AddAttributeError(frameworkPath(d.AttributePath), d.Summary, d.Detail)
+		default:
+			converted.AddError(d.Summary, d.Detail)
+		}
+	}
+
+	return converted
+}
+
+// frameworkPath converts a cty.Path to the path of the same attribute in a framework schema
+func frameworkPath(p cty.Path) path.Path {
+	var converted path.Path
+
+	for i, step := range p {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			if i == 0 {
+				converted = path.Root(step.Name)
+			} else {
+				converted = converted.AtName(step.Name)
+			}
+		case cty.IndexStep:
+			if step.Key.Type().Equals(cty.Number) {
+				n, _ := step.Key.AsBigFloat().Int64()
+				converted = converted.AtListIndex(int(n))
+			} else {
+				converted = converted.AtMapKey(step.Key.AsString())
+			}
+		}
+	}
+
+	return converted
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestSourceDiagnostics_apiErrors(t *testing.T) {
+	cases := map[string]struct {
+		status      int
+		body        string
+		wantSummary string
+		wantDetail  string
+		wantPath    path.Path
+	}{
+		"validation error": {
+			status:      http.StatusUnprocessableEntity,
+			body:        `[{"code":"invalid","title":"Validation Error","detail":"role_arn already exists","path":"role_arn"}]`,
+			wantSummary: "Unable to create source 'example': Validation Error",
+			wantDetail:  "422 Unprocessable Entity: role_arn already exists",
+			wantPath:    path.Root("aws_cross_account_role").AtListIndex(0).AtName("role_arn"),
+		},
+		"validation error on a write-only attribute": {
+			status:      http.StatusUnprocessableEntity,
+			body:        `[{"code":"invalid","title":"Validation Error","detail":"external_id is too short","path":"external_id"}]`,
+			wantSummary: "Unable to create source 'example': Validation Error",
+			wantDetail:  "external_id is too short",
+			wantPath:    path.Root("aws_cross_account_role").AtListIndex(0).AtName("external_id_wo"),
+		},
+		"validation error on an unknown field": {
+			status:      http.StatusUnprocessableEntity,
+			body:        `[{"code":"invalid","title":"Validation Error","detail":"account is suspended","path":"account"}]`,
+			wantSummary: "Unable to create source 'example': Validation Error",
+			wantDetail:  "account is suspended",
+		},
+		"unauthorized": {
+			status:      http.StatusUnauthorized,
+			wantSummary: "Unable to create source 'example'",
+			wantDetail:  "HAVA_TOKEN",
+		},
+		"forbidden": {
+			status:      http.StatusForbidden,
+			wantSummary: "Unable to create source 'example'",
+			wantDetail:  "plan",
+		},
+		"server error": {
+			status:      http.StatusInternalServerError,
+			body:        `upstream unavailable`,
+			wantSummary: "Unable to create source 'example'",
+			wantDetail:  "500 Internal Server Error.\n\nupstream unavailable",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+
+			_, diags := testCreateSource(t, client, map[string]any{
+				"name":                              "example",
+				"aws_cross_account_role.0.role_arn": "arn:aws:iam::123456789012:role/Hava",
+			})
+
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, got %+v", diags)
+			}
+
+			if diags[0].Summary() != tc.wantSummary {
+				t.Errorf("expected summary %q, got %q", tc.wantSummary, diags[0].Summary())
+			}
+
+			if !strings.Contains(diags[0].Detail(), tc.wantDetail) {
+				t.Errorf("expected detail to contain %q, got %q", tc.wantDetail, diags[0].Detail())
+			}
+
+			if got := testDiagnosticPath(diags[0]); !got.Equal(tc.wantPath) {
+				t.Errorf("expected attribute path %s, got %s", tc.wantPath, got)
+			}
+		})
+	}
+}
+
+func TestFrameworkDiagnostics(t *testing.T) {
+	diags := frameworkDiagnostics(diag.Diagnostics{
+		{
+			Severity:      diag.Error,
+			Summary:       "error",
+			AttributePath: cty.GetAttrPath("aws_cross_account_role").IndexInt(0).GetAttr("role_arn"),
+		},
+		{
+			Severity: diag.Warning,
+			Summary:  "warning",
+		},
+	})
+
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %+v", diags)
+	}
+
+	if diags[0].Severity() != fwdiag.SeverityError || diags[1].Severity() != fwdiag.SeverityWarning {
+		t.Errorf("expected an error and a warning, got %+v", diags)
+	}
+
+	want := path.Root("aws_cross_account_role").AtListIndex(0).AtName("role_arn")
+
+	if d, ok := diags[0].(fwdiag.DiagnosticWithPath); !ok || !d.Path().Equal(want) {
+		t.Errorf("expected the error to point at %s, got %+v", want, diags[0])
+	}
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ provider.Provider = &frameworkProvider{}
+
+// frameworkProvider is the terraform-plugin-framework half of the provider. It is muxed
+// together with the SDKv2 provider returned by New, see NewMuxServer, while resources and
+// data sources are migrated to the framework one at a time.
+//
+// Terraform requires both halves to have identical provider schemas, so any attribute
+// added to the SDKv2 provider schema must be added here as well.
+type frameworkProvider struct {
+	version string
+}
+
+type frameworkProviderModel struct {
+	APIToken        types.String `tfsdk:"api_token"`
+	APITokenCommand types.String `tfsdk:"api_token_command"`
+	Endpoint        types.String `tfsdk:"endpoint"`
+	Profile         types.String `tfsdk:"profile"`
+	ConfigFile      types.String `tfsdk:"config_file"`
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait    types.Int64  `tfsdk:"retry_max_wait"`
+
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+
+	CABundleFile       types.String `tfsdk:"ca_bundle_file"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	SkipCredentialsValidation types.Bool `tfsdk:"skip_credentials_validation"`
+}
+
+func NewFrameworkProvider(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &frameworkProvider{
+			version: version,
+		}
+	}
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "hava"
+	resp.Version = p.version
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"api_token": schema.StringAttribute{
+				MarkdownDescription: apiTokenDescription,
+				Optional:            true,
+			},
+			"api_token_command": schema.StringAttribute{
+				MarkdownDescription: apiTokenCommandDescription,
+				Optional:            true,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: endpointDescription,
+				Optional:            true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: profileDescription,
+				Optional:            true,
+			},
+			"config_file": schema.StringAttribute{
+				MarkdownDescription: configFileDescription,
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: maxRetriesDescription,
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				MarkdownDescription: retryMaxWaitDescription,
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: requestsPerSecondDescription,
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: maxConcurrentRequestsDescription,
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"ca_bundle_file": schema.StringAttribute{
+				MarkdownDescription: caBundleFileDescription,
+				Optional:            true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				MarkdownDescription: clientCertFileDescription,
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_file")),
+				},
+			},
+			"client_key_file": schema.StringAttribute{
+				MarkdownDescription: clientKeyFileDescription,
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_file")),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: proxyURLDescription,
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: insecureSkipVerifyDescription,
+				Optional:            true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				MarkdownDescription: skipCredentialsValidationDescription,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var data frameworkProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, endpoint, err := credentialSettings{
+		APIToken:        data.APIToken.ValueString(),
+		APITokenCommand: data.APITokenCommand.ValueString(),
+		Endpoint:        data.Endpoint.ValueString(),
+		Profile:         data.Profile.ValueString(),
+		ConfigFile:      data.ConfigFile.ValueString(),
+	}.resolve(ctx)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to configure the Hava client", err.Error())
+		return
+	}
+
+	// apply the same defaults as the SDKv2 provider schema
+	config := providerConfig{
+		APIToken:     token,
+		Endpoint:     endpoint,
+		MaxRetries:   defaultMaxRetries,
+		RetryMaxWait: defaultRetryMaxWait,
+
+		CABundleFile:       data.CABundleFile.ValueString(),
+		ClientCertFile:     data.ClientCertFile.ValueString(),
+		ClientKeyFile:      data.ClientKeyFile.ValueString(),
+		ProxyURL:           data.ProxyURL.ValueString(),
+		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+	}
+
+	if !data.MaxRetries.IsNull() {
+		config.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
+	if !data.RetryMaxWait.IsNull() {
+		config.RetryMaxWait = time.Duration(data.RetryMaxWait.ValueInt64()) * time.Second
+	}
+
+	if !data.RequestsPerSecond.IsNull() {
+		config.RequestsPerSecond = data.RequestsPerSecond.ValueFloat64()
+	}
+
+	if !data.MaxConcurrentRequests.IsNull() {
+		config.MaxConcurrentRequests = int(data.MaxConcurrentRequests.ValueInt64())
+	}
+
+	userAgent := fmt.Sprintf("Terraform/%s (+https://www.terraform.io) terraform-provider-hava/%s", req.TerraformVersion, p.version)
+
+	client, err := config.client(ctx, userAgent)
+
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to configure the Hava client", err.Error())
+		return
+	}
+
+	if !data.SkipCredentialsValidation.ValueBool() {
+		if err := config.validateCredentials(ctx, client); err != nil {
+			resp.Diagnostics.AddError(err.Summary, err.Detail)
+			return
+		}
+	}
+
+	resp.DataSourceData = client
+	resp.ResourceData = client
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		NewAWSCARTrustPolicyDataSource,
+		NewSourceDataSource,
+		NewSourcesDataSource,
+	}
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		NewSourceResource,
+		NewSourceSyncResource,
+		NewSourceGCPCredentialsResource,
+	}
+}
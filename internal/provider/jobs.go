@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	havaclient "github.com/teamhava/hava-sdk-go"
+)
+
+// jobStateComplete is the state of a completed job, which the API usually doesn't report as
+// it redirects to the result of the job instead
+const jobStateComplete = "complete"
+
+// jobIDFromLocation returns the ID of the job the Location header of an accepted request
+// points to, which is either the URL of the job or just its ID
+func jobIDFromLocation(location string) (string, error) {
+	u, err := url.Parse(location)
+
+	if err != nil {
+		return "", fmt.Errorf("unable to parse job location '%s': %w", location, err)
+	}
+
+	id := path.Base(strings.TrimSuffix(u.Path, "/"))
+
+	if id == "" || id == "." || id == "/" {
+		return "", fmt.Errorf("no job ID found in location '%s'", location)
+	}
+
+	return id, nil
+}
+
+// getJobState returns the state of a job. The SDK has no method for the jobs endpoint, so
+// the request is sent with the SDK's HTTP client and headers, which keeps it within the
+// retry and rate limits of the provider.
+func getJobState(ctx context.Context, client *havaclient.APIClient, id string) (string, error) {
+	cfg := client.GetConfig()
+
+	base, err := cfg.Servers.URL(0, nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(base, "/")+"/jobs/"+url.PathEscape(id), nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	for k, v := range cfg.DefaultHeader {
+		req.Header.Set(k, v)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", cfg.UserAgent)
+
+	httpClient := http.DefaultClient
+
+	if cfg.HTTPClient != nil {
+		httpClient = cfg.HTTPClient
+	}
+
+	// a completed job redirects to its result, which is all we need to know
+	noRedirects := *httpClient
+	noRedirects.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	res, err := noRedirects.Do(req)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case res.StatusCode == http.StatusSeeOther:
+		return jobStateComplete, nil
+	case res.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("unable to read job '%s': %s", id, res.Status)
+	}
+
+	var job havaclient.JobsShow200Response
+
+	if err := json.Unmarshal(body, &job); err != nil {
+		return "", fmt.Errorf("unable to read job '%s': %w", id, err)
+	}
+
+	return job.GetState(), nil
+}
+
+// waitForJob polls a job until it completes, returning an error if the job fails or doesn't
+// complete within timeout
+func waitForJob(ctx context.Context, client *havaclient.APIClient, id string, timeout time.Duration) (string, error) {
+	tflog.Info(ctx, fmt.Sprintf("waiting for job '%s' to complete", id))
+
+	conf := &retry.StateChangeConf{
+		Pending: []string{"queued", "active"},
+		Target:  []string{jobStateComplete},
+		Refresh: func() (any, string, error) {
+			state, err := getJobState(ctx, client, id)
+
+			if err != nil {
+				return nil, "", err
+			}
+
+			return state, state, nil
+		},
+		Timeout:      timeout,
+		PollInterval: sourceImportPollInterval,
+	}
+
+	result, err := conf.WaitForStateContext(ctx)
+
+	state, _ := result.(string)
+
+	if err == nil {
+		return state, nil
+	}
+
+	if state == "failed" || state == "cancelled" {
+		return state, fmt.Errorf("job '%s' %s, check the source and its import history in Hava", id, state)
+	}
+
+	return state, fmt.Errorf("error waiting for job '%s' to complete: %w", id, err)
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// sourceCredentialFields are the fields of source requests that hold credentials, or
+// identify the cloud account they give access to. Their values are masked in the logs.
+var sourceCredentialFields = []string{
+	"access_key",
+	"client_id",
+	"encoded_file",
+	"external_id",
+	"role_arn",
+	"secret_key",
+	"subscription_id",
+	"tenant_id",
+}
+
+// logSourceRequest logs the body of a request to create or update a source at debug level,
+// with the credentials masked
+func logSourceRequest(ctx context.Context, msg string, body any) {
+	b, err := json.Marshal(body)
+
+	if err != nil {
+		return
+	}
+
+	var fields map[string]any
+
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return
+	}
+
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sourceCredentialFields...)
+
+	tflog.Debug(ctx, msg, fields)
+}
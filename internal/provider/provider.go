@@ -2,10 +2,11 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	havaclient "github.com/teamhava/hava-sdk-go"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func init() {
@@ -27,27 +28,104 @@ func init() {
 func New(version string) func() *schema.Provider {
 	return func() *schema.Provider {
 		p := &schema.Provider{
-			
-			DataSourcesMap: map[string]*schema.Resource{
-			},
+
+			// data sources are served by the framework provider, see NewFrameworkProvider
+			DataSourcesMap: map[string]*schema.Resource{},
+			// hava_source, hava_source_sync and hava_source_gcp_sa_credentials_resource are
+			// served by the framework provider, only the other deprecated per-type resources
+			// remain here
 			ResourcesMap: map[string]*schema.Resource{
 				"hava_source_aws_car_resource":           resourceHavaSourceAWSCAR(),
 				"hava_source_aws_key_resource":           resourceHavaSourceAWSKey(),
 				"hava_source_azure_credentials_resource": resourceHavaSourceAzureCredentials(),
-				"hava_source_gcp_sa_credentials_resource": resourceHavaSourceGCPCredentials(),
 			},
 			Schema: map[string]*schema.Schema{
 				"api_token": {
-					Description: "The API token to authenticate with the Hava API. This takes precedence over the 'HAVA_TOKEN' environment variable.",
+					Description: apiTokenDescription,
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"api_token_command": {
+					Description: apiTokenCommandDescription,
 					Type:        schema.TypeString,
 					Optional:    true,
-					DefaultFunc: schema.EnvDefaultFunc("HAVA_TOKEN", nil),
 				},
 				"endpoint": {
-					Description: "Which API endpoint to connect to. This is primarily used to support self-hosted users that does not use the default SaaS API endpoints",
-					Type:	schema.TypeString,
-					Optional: true,
-					Default: "https://api.hava.io",
+					Description: endpointDescription,
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"profile": {
+					Description: profileDescription,
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"config_file": {
+					Description: configFileDescription,
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"max_retries": {
+					Description:  maxRetriesDescription,
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      defaultMaxRetries,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"retry_max_wait": {
+					Description:  retryMaxWaitDescription,
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      int(defaultRetryMaxWait / time.Second),
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"requests_per_second": {
+					Description:  requestsPerSecondDescription,
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					Default:      0.0,
+					ValidateFunc: validation.FloatAtLeast(0),
+				},
+				"max_concurrent_requests": {
+					Description:  maxConcurrentRequestsDescription,
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"ca_bundle_file": {
+					Description: caBundleFileDescription,
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"client_cert_file": {
+					Description:  clientCertFileDescription,
+					Type:         schema.TypeString,
+					Optional:     true,
+					RequiredWith: []string{"client_key_file"},
+				},
+				"client_key_file": {
+					Description:  clientKeyFileDescription,
+					Type:         schema.TypeString,
+					Optional:     true,
+					RequiredWith: []string{"client_cert_file"},
+				},
+				"proxy_url": {
+					Description: proxyURLDescription,
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"insecure_skip_verify": {
+					Description: insecureSkipVerifyDescription,
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+				"skip_credentials_validation": {
+					Description: skipCredentialsValidationDescription,
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
 				},
 			},
 		}
@@ -61,26 +139,51 @@ func New(version string) func() *schema.Provider {
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (any, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
 
-		token, ok := d.GetOk("api_token")
-		endpoint := d.Get("endpoint").(string)
+		token, endpoint, err := credentialSettings{
+			APIToken:        d.Get("api_token").(string),
+			APITokenCommand: d.Get("api_token_command").(string),
+			Endpoint:        d.Get("endpoint").(string),
+			Profile:         d.Get("profile").(string),
+			ConfigFile:      d.Get("config_file").(string),
+		}.resolve(ctx)
 
-		if !ok {
-			return nil, diag.Errorf("api token not found, did you set the 'HAVA_TOKEN' environment variable")
+		if err != nil {
+			return nil, diag.FromErr(err)
 		}
 
-		cfg := havaclient.NewConfiguration()
-		cfg.Servers = havaclient.ServerConfigurations{
-			{
-				URL: endpoint,
-				Description: "No description provided",
-			},
+		config := providerConfig{
+			APIToken:     token,
+			Endpoint:     endpoint,
+			MaxRetries:   d.Get("max_retries").(int),
+			RetryMaxWait: time.Duration(d.Get("retry_max_wait").(int)) * time.Second,
+
+			RequestsPerSecond:     d.Get("requests_per_second").(float64),
+			MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+
+			CABundleFile:       d.Get("ca_bundle_file").(string),
+			ClientCertFile:     d.Get("client_cert_file").(string),
+			ClientKeyFile:      d.Get("client_key_file").(string),
+			ProxyURL:           d.Get("proxy_url").(string),
+			InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
 		}
 
-		cfg.UserAgent = p.UserAgent("terraform-provider-hava", version)
+		myclient, err := config.client(ctx, p.UserAgent("terraform-provider-hava", version))
 
-		cfg.DefaultHeader["Authorization"] = "Bearer " + token.(string)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
 
-		myclient := havaclient.NewAPIClient(cfg)
+		if !d.Get("skip_credentials_validation").(bool) {
+			if err := config.validateCredentials(ctx, myclient); err != nil {
+				return nil, diag.Diagnostics{
+					{
+						Severity: diag.Error,
+						Summary:  err.Summary,
+						Detail:   err.Detail,
+					},
+				}
+			}
+		}
 
 		return myclient, nil
 	}
@@ -1,17 +1,24 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
-// providerFactories are used to instantiate a provider during acceptance testing.
+// protoV6ProviderFactories are used to instantiate a provider during acceptance testing.
 // The factory function will be invoked for every Terraform CLI command executed
 // to create a provider server to which the CLI can reattach.
-var providerFactories = map[string]func() (*schema.Provider, error){
-	"scaffolding": func() (*schema.Provider, error) {
-		return New("dev")(), nil
+var protoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"hava": func() (tfprotov6.ProviderServer, error) {
+		serverFactory, err := NewMuxServer(context.Background(), "dev")
+
+		if err != nil {
+			return nil, err
+		}
+
+		return serverFactory(), nil
 	},
 }
 
@@ -21,6 +28,38 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+// TestMuxServer makes sure the SDKv2 and framework providers can be served together, which
+// requires their provider schemas to be identical
+func TestMuxServer(t *testing.T) {
+	serverFactory, err := NewMuxServer(context.Background(), "dev")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resp, err := serverFactory().GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	for _, name := range []string{"hava_source", "hava_source_aws_car_resource", "hava_source_gcp_sa_credentials_resource", "hava_source_sync"} {
+		if _, ok := resp.ResourceSchemas[name]; !ok {
+			t.Errorf("expected resource %s to be served", name)
+		}
+	}
+
+	for _, name := range []string{"hava_aws_car_trust_policy", "hava_source", "hava_sources"} {
+		if _, ok := resp.DataSourceSchemas[name]; !ok {
+			t.Errorf("expected data source %s to be served", name)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	// You can add code here to run prior to any test case execution, for example assertions
 	// about the appropriate environment variables being set are common to see in a pre-check
@@ -0,0 +1,746 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	havaclient "github.com/teamhava/hava-sdk-go"
+)
+
+// sourceKinds lists every kind of source that can be configured through hava_source
+var sourceKinds = []sourceKind{
+	sourceKindAWSCAR,
+	sourceKindAWSKey,
+	sourceKindAzureCredentials,
+	sourceKindGCPServiceAccount,
+}
+
+var (
+	_ resource.ResourceWithConfigure      = &sourceResource{}
+	_ resource.ResourceWithValidateConfig = &sourceResource{}
+	_ resource.ResourceWithModifyPlan     = &sourceResource{}
+	_ resource.ResourceWithImportState    = &sourceResource{}
+)
+
+func NewSourceResource() resource.Resource {
+	return &sourceResource{}
+}
+
+type sourceResource struct {
+	client *havaclient.APIClient
+}
+
+type sourceResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Type               types.String   `tfsdk:"type"`
+	State              types.String   `tfsdk:"state"`
+	CredentialsVersion types.Int64    `tfsdk:"credentials_version"`
+	WaitForImport      types.Bool     `tfsdk:"wait_for_import"`
+	AdoptExisting      types.Bool     `tfsdk:"adopt_existing"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+
+	AWSCrossAccountRole   types.List `tfsdk:"aws_cross_account_role"`
+	AWSAccessKey          types.List `tfsdk:"aws_access_key"`
+	AzureServicePrincipal types.List `tfsdk:"azure_service_principal"`
+	GCPServiceAccount     types.List `tfsdk:"gcp_service_account"`
+}
+
+// credentialBlocks returns the credentials blocks of the model by block name
+func (m *sourceResourceModel) credentialBlocks() map[string]*types.List {
+	return map[string]*types.List{
+		sourceKindAWSCAR.Block:            &m.AWSCrossAccountRole,
+		sourceKindAWSKey.Block:            &m.AWSAccessKey,
+		sourceKindAzureCredentials.Block:  &m.AzureServicePrincipal,
+		sourceKindGCPServiceAccount.Block: &m.GCPServiceAccount,
+	}
+}
+
+// configuredKind returns the kind of source whose credentials block is set, or false if no
+// block is set or it isn't known yet
+func (m *sourceResourceModel) configuredKind() (sourceKind, bool) {
+	blocks := m.credentialBlocks()
+
+	for _, kind := range sourceKinds {
+		if block := blocks[kind.Block]; !block.IsUnknown() && len(block.Elements()) == 1 {
+			return kind, true
+		}
+	}
+
+	return sourceKind{}, false
+}
+
+// credentialAttributes returns the attributes of the credentials block of kind
+func (m *sourceResourceModel) credentialAttributes(kind sourceKind) map[string]attr.Value {
+	block := m.credentialBlocks()[kind.Block]
+
+	if len(block.Elements()) == 0 {
+		return nil
+	}
+
+	credentials, ok := block.Elements()[0].(types.Object)
+
+	if !ok {
+		return nil
+	}
+
+	return credentials.Attributes()
+}
+
+// setCredentialAttributes replaces attributes of the credentials block of kind with values
+func (m *sourceResourceModel) setCredentialAttributes(kind sourceKind, values map[string]attr.Value) {
+	attributes := maps.Clone(m.credentialAttributes(kind))
+	maps.Copy(attributes, values)
+
+	*m.credentialBlocks()[kind.Block] = types.ListValueMust(kind.frameworkObjectType(), []attr.Value{
+		types.ObjectValueMust(kind.frameworkObjectType().AttrTypes, attributes),
+	})
+}
+
+// credentials returns the credentials configured in the credentials block of kind, or nil
+// when any of them isn't known yet, which can only happen while planning. Write-only values
+// are never part of the plan, so they are read from config.
+func (m *sourceResourceModel) credentials(ctx context.Context, config tfsdk.Config, kind sourceKind) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	attributes := m.credentialAttributes(kind)
+
+	credentials := map[string]string{}
+
+	for _, a := range kind.Attributes {
+		if a.Computed {
+			continue
+		}
+
+		v, _ := attributes[a.Name].(types.String)
+
+		if v.IsNull() && a.Secret {
+			diags.Append(config.GetAttribute(ctx, path.Root(kind.Block).AtListIndex(0).AtName(a.Name+writeOnlySuffix), &v)...)
+		}
+
+		if v.IsUnknown() {
+			return nil, diags
+		}
+
+		credentials[a.Name] = v.ValueString()
+	}
+
+	return credentials, diags
+}
+
+// credentialsChanged reports if the credentials of kind configured in m differ from those in
+// prior. Write-only values are never known to differ, which is what credentials_version is for.
+func (m *sourceResourceModel) credentialsChanged(prior *sourceResourceModel, kind sourceKind) bool {
+	attributes, priorAttributes := m.credentialAttributes(kind), prior.credentialAttributes(kind)
+
+	for _, a := range kind.Attributes {
+		if a.Computed {
+			continue
+		}
+
+		if attributes[a.Name] == nil || !attributes[a.Name].Equal(priorAttributes[a.Name]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// metadata returns the computed attributes of kind set in m
+func (m *sourceResourceModel) metadata(kind sourceKind) map[string]string {
+	attributes := m.credentialAttributes(kind)
+
+	metadata := map[string]string{}
+
+	for _, a := range kind.Attributes {
+		if v, ok := attributes[a.Name].(types.String); ok && a.Computed && !v.IsNull() && !v.IsUnknown() {
+			metadata[a.Name] = v.ValueString()
+		}
+	}
+
+	return metadata
+}
+
+// setMetadata sets the computed attributes kind derives from the credentials. They are
+// unknown when credentials is nil, because the credentials aren't known yet, and null when
+// they can't be derived.
+func (m *sourceResourceModel) setMetadata(kind sourceKind, credentials map[string]string) {
+	if kind.Metadata == nil {
+		return
+	}
+
+	var metadata map[string]string
+
+	if credentials != nil {
+		metadata = kind.Metadata(credentials)
+	}
+
+	values := map[string]attr.Value{}
+
+	for _, a := range kind.Attributes {
+		if !a.Computed {
+			continue
+		}
+
+		if v, ok := metadata[a.Name]; ok {
+			values[a.Name] = types.StringValue(v)
+		} else if credentials == nil {
+			values[a.Name] = types.StringUnknown()
+		} else {
+			values[a.Name] = types.StringNull()
+		}
+	}
+
+	m.setCredentialAttributes(kind, values)
+}
+
+// sourceRequest returns the request for an operation on the source of kind configured in m
+func (m *sourceResourceModel) sourceRequest(ctx context.Context, config tfsdk.Config, kind sourceKind, timeout time.Duration) (sourceRequest, diag.Diagnostics) {
+	r := sourceRequest{
+		Kind:          kind,
+		ID:            m.ID.ValueString(),
+		Name:          m.Name.ValueString(),
+		AdoptExisting: m.AdoptExisting.ValueBool(),
+		WaitForImport: m.WaitForImport.ValueBool(),
+		Timeout:       timeout,
+	}
+
+	credentials, diags := m.credentials(ctx, config, kind)
+
+	r.Credentials = credentials
+
+	attributes := m.credentialAttributes(kind)
+
+	r.AttributePath = func(field string) cty.Path {
+		if field == "name" {
+			return cty.GetAttrPath(field)
+		}
+
+		for _, a := range kind.Attributes {
+			if a.Name != field {
+				continue
+			}
+
+			if a.Secret && (attributes[a.Name] == nil || attributes[a.Name].IsNull()) {
+				field += writeOnlySuffix
+			}
+
+			return cty.GetAttrPath(kind.Block).IndexInt(0).GetAttr(field)
+		}
+
+		return nil
+	}
+
+	return r, diags
+}
+
+// frameworkObjectType returns the type of the credentials block of the kind
+func (k sourceKind) frameworkObjectType() types.ObjectType {
+	attrTypes := map[string]attr.Type{}
+
+	for _, a := range k.Attributes {
+		attrTypes[a.Name] = types.StringType
+
+		if a.Secret {
+			attrTypes[a.Name+writeOnlySuffix] = types.StringType
+		}
+	}
+
+	return types.ObjectType{AttrTypes: attrTypes}
+}
+
+// frameworkAttributes returns the credential attributes of the kind as attributes of a
+// framework schema, at the top level or nested in a credentials block
+func (k sourceKind) frameworkAttributes() map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{}
+
+	for _, a := range k.Attributes {
+		var validators []validator.String
+
+		if a.Format != nil {
+			validators = append(validators, a.Format.frameworkValidator())
+		}
+
+		switch {
+		case a.Computed:
+			attributes[a.Name] = schema.StringAttribute{
+				MarkdownDescription: a.Description,
+				Computed:            true,
+			}
+		case a.Secret:
+			attributes[a.Name] = schema.StringAttribute{
+				MarkdownDescription: secretCredentialDescription(a.Name, a.Description),
+				Optional:            true,
+				Sensitive:           true,
+				Validators: append(validators, stringvalidator.ExactlyOneOf(
+					path.MatchRelative().AtParent().AtName(a.Name+writeOnlySuffix),
+				)),
+			}
+			attributes[a.Name+writeOnlySuffix] = schema.StringAttribute{
+				MarkdownDescription: writeOnlyCredentialDescription(a.Description),
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: append(validators, stringvalidator.ExactlyOneOf(
+					path.MatchRelative().AtParent().AtName(a.Name),
+				)),
+			}
+		default:
+			attributes[a.Name] = schema.StringAttribute{
+				MarkdownDescription: a.Description,
+				Required:            true,
+				Sensitive:           true,
+				Validators:          validators,
+			}
+		}
+	}
+
+	return attributes
+}
+
+// frameworkBlock returns the credentials block of the kind in the hava_source schema
+func (k sourceKind) frameworkBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		MarkdownDescription: k.Description + ". Exactly one credentials block must be set.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: k.frameworkAttributes(),
+		},
+		Validators: []validator.List{
+			listvalidator.SizeAtMost(1),
+		},
+	}
+}
+
+func (r *sourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source"
+}
+
+func (r *sourceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	blocks := map[string]schema.Block{
+		"timeouts": timeouts.Block(ctx, timeouts.Opts{
+			Create: true,
+			Read:   true,
+			Update: true,
+			Delete: true,
+		}),
+	}
+
+	for _, kind := range sourceKinds {
+		blocks[kind.Block] = kind.frameworkBlock()
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A Source in Hava for an AWS account, Azure subscription or GCP project, configured by exactly one of the credentials blocks.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the source",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: sourceNameDescription,
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the Source in Hava, derived from the credentials block that is set",
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: sourceStateDescription,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"credentials_version": schema.Int64Attribute{
+				MarkdownDescription: sourceCredentialsVersionDescription,
+				Optional:            true,
+			},
+			"wait_for_import": schema.BoolAttribute{
+				MarkdownDescription: sourceWaitForImportDescription,
+				Optional:            true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: sourceAdoptExistingDescription,
+				Optional:            true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: sourceDeletionProtectionDescription,
+				Optional:            true,
+			},
+		},
+
+		Blocks: blocks,
+	}
+}
+
+func (r *sourceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*havaclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *havaclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *sourceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data sourceResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blocks := make([]string, 0, len(sourceKinds))
+	set := 0
+
+	for _, kind := range sourceKinds {
+		block := data.credentialBlocks()[kind.Block]
+
+		if block.IsUnknown() {
+			// e.g. a dynamic block over a value that isn't known yet
+			return
+		}
+
+		blocks = append(blocks, kind.Block)
+		set += len(block.Elements())
+	}
+
+	if set != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid credentials",
+			fmt.Sprintf("Exactly one credentials block must be set, one of %s.", strings.Join(blocks, ", ")),
+		)
+	}
+}
+
+func (r *sourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// the source is destroyed
+		return
+	}
+
+	var plan sourceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind, ok := plan.configuredKind()
+
+	if !ok {
+		return
+	}
+
+	plan.Type = types.StringValue(kind.Type)
+
+	var state sourceResourceModel
+
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// a source can't change type in Hava, so switching to another credentials block
+		// replaces the source, while changes within the same block are updated in place
+		priorBlocks := state.credentialBlocks()
+
+		for block, credentials := range plan.credentialBlocks() {
+			if len(credentials.Elements()) != len(priorBlocks[block].Elements()) {
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root(block))
+			}
+		}
+	}
+
+	if kind.Metadata != nil {
+		// plan the attributes derived from the credentials, so that changes to them, such as
+		// a GCP source switching to another project, show up in the plan
+		credentials, diags := plan.credentials(ctx, req.Config, kind)
+
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		plan.setMetadata(kind, credentials)
+
+		if kind.MetadataWarnings != nil && credentials != nil {
+			resp.Diagnostics.Append(frameworkDiagnostics(kind.MetadataWarnings(state.metadata(kind), plan.metadata(kind)))...)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *sourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating")
+
+	var plan sourceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, sourceCreateTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	kind, ok := plan.configuredKind()
+
+	if !ok {
+		resp.Diagnostics.AddError("Unable to create source", "No credentials block is set.")
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	sr, diags := plan.sourceRequest(ctx, req.Config, kind, timeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source, sdkDiags := createSource(ctx, r.client, sr)
+
+	resp.Diagnostics.Append(frameworkDiagnostics(sdkDiags)...)
+
+	if source == nil {
+		return
+	}
+
+	plan.ID = types.StringValue(source.GetId())
+	plan.Type = types.StringValue(kind.Type)
+	plan.State = types.StringValue(source.GetState())
+	plan.setMetadata(kind, sr.Credentials)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *sourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading")
+
+	var state sourceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Read(ctx, sourceDefaultTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	source, err := readSource(ctx, r.client, state.ID.ValueString())
+
+	if err != nil {
+		sr := sourceRequest{
+			ID:      state.ID.ValueString(),
+			Name:    state.Name.ValueString(),
+			Timeout: timeout,
+		}
+
+		resp.Diagnostics.Append(frameworkDiagnostics(sr.diagnostics(sdkschema.TimeoutRead, err))...)
+		return
+	}
+
+	if source == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Name = types.StringValue(source.GetName())
+	state.Type = types.StringValue(source.GetType())
+	state.State = types.StringValue(source.GetState())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *sourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	tflog.Info(ctx, "updating")
+
+	var plan, state sourceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Update(ctx, sourceDefaultTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	kind, ok := plan.configuredKind()
+
+	if !ok {
+		resp.Diagnostics.AddError("Unable to update source", "No credentials block is set.")
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	plan.ID = state.ID
+
+	sr, diags := plan.sourceRequest(ctx, req.Config, kind, timeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// changes to settings of the provider, such as deletion_protection, aren't sent to Hava
+	if !plan.Name.Equal(state.Name) || !plan.CredentialsVersion.Equal(state.CredentialsVersion) || plan.credentialsChanged(&state, kind) {
+		resp.Diagnostics.Append(frameworkDiagnostics(updateSource(ctx, r.client, sr))...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	plan.setMetadata(kind, sr.Credentials)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *sourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "deleting")
+
+	var state sourceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, sourceDefaultTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	sr := sourceRequest{
+		ID:      state.ID.ValueString(),
+		Name:    state.Name.ValueString(),
+		Timeout: timeout,
+	}
+
+	resp.Diagnostics.Append(frameworkDiagnostics(deleteSource(ctx, r.client, sr, state.DeletionProtection.ValueBool()))...)
+}
+
+// ImportState imports a source of any supported type, filling in the credentials block
+// matching the type of the source with the non-secret values the API returns.
+func (r *sourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing")
+
+	source, res, err := r.client.SourcesApi.SourcesShow(ctx, req.ID).Execute()
+
+	if err != nil {
+		resp.Diagnostics.Append(frameworkAPIErrorDiagnostics(fmt.Sprintf("Unable to import source '%s'", req.ID), newAPIError(res, err))...)
+		return
+	}
+
+	var imported bool
+
+	for _, kind := range sourceKinds {
+		elements := []attr.Value{}
+
+		if kind.Type == source.GetType() {
+			imported = true
+
+			attributes := map[string]attr.Value{}
+
+			for name := range kind.frameworkObjectType().AttrTypes {
+				attributes[name] = types.StringNull()
+			}
+
+			if kind.InfoAttribute != "" && source.Info != nil {
+				attributes[kind.InfoAttribute] = types.StringValue(source.GetInfo())
+			}
+
+			elements = append(elements, types.ObjectValueMust(kind.frameworkObjectType().AttrTypes, attributes))
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(kind.Block), types.ListValueMust(kind.frameworkObjectType(), elements))...)
+	}
+
+	if !imported {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to import source '%s'", req.ID),
+			fmt.Sprintf("Source '%s' is of type '%s', which is not supported by this provider.", req.ID, source.GetType()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), source.GetId())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), source.GetName())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), source.GetType())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("state"), source.GetState())...)
+}
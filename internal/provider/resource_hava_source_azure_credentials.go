@@ -2,92 +2,56 @@ package provider
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	havaclient "github.com/teamhava/hava-sdk-go"
 )
 
-func resourceHavaSourceAzureCredentials() *schema.Resource {
-	return &schema.Resource{
-		// This description is used by the documentation generator and the language server.
-		Description: "A Source in Hava using an Azure service principal to authenticate to the Azure subscription that will be imported.",
+var sourceKindAzureCredentials = sourceKind{
+	Type:        sourceTypeAzureCredentials,
+	Block:       "azure_service_principal",
+	Description: "Authenticate to the Azure subscription using a service principal",
 
-		CreateContext: resourceSourceAzureCredentialsCreate,
-		ReadContext:   resourceSourceAzureCredentialsRead,
-		UpdateContext: resourceSourceAzureCredentialsUpdate,
-		DeleteContext: resourceSourceAzureCredentialsDelete,
-
-		Schema: map[string]*schema.Schema{
-			"name": {
-				// This description is used by the documentation generator and the language server.
-				Description: "Display name of the source",
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			"subscription_id": {
-				Description: "The id of the azure subscription that will be accessed to import the data",
-				Sensitive:   true,
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			"tenant_id": {
-				Description: "The id of the azure tenant that will be accessed to import the data",
-				Sensitive:   true,
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			"client_id": {
-				Description: "The id of client that will be used to access the source for import",
-				Sensitive:   true,
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			"secret_key": {
-				Description: "The azure secret key of the client that will be used to access the source for import",
-				Sensitive:   true,
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			"state": {
-				Description: "State of the Source",
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
+	Attributes: []credentialAttribute{
+		{
+			Name:        "subscription_id",
+			Description: "The id of the azure subscription that will be accessed to import the data",
+			Format:      validateGUID("subscription ID"),
 		},
-		CustomizeDiff: customdiff.All(
-
-			// if state is set to archived, it has been deleted outside of terraform and a new resource needs to be created
-			customdiff.ForceNewIf("state", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
-				state := d.Get("state").(string)
-
-				if state == "archived" {
-					tflog.Info(ctx, fmt.Sprintf("Source '%s' was deleted outside of terraform, a new resource will be created in it's place", d.Id()))
-					d.SetNewComputed("state")
-					return true
-				}
-				return false
-			}),
-		),
-	}
+		{
+			Name:        "tenant_id",
+			Description: "The id of the azure tenant that will be accessed to import the data",
+			Format:      validateGUID("tenant ID"),
+		},
+		{
+			Name:        "client_id",
+			Description: "The id of client that will be used to access the source for import",
+			Format:      validateGUID("client ID"),
+		},
+		{
+			Name:        "secret_key",
+			Description: "The azure secret key of the client that will be used to access the source for import",
+			Secret:      true,
+		},
+	},
+	CreateRequest: func(name string, credentials map[string]string) havaclient.SourcesCreateRequest {
+		return havaclient.SourcesAzureCredentialsAsSourcesCreateRequest(expandSourceAzureCredentials(name, credentials))
+	},
+	UpdateRequest: func(name string, credentials map[string]string) havaclient.SourcesUpdateRequest {
+		return havaclient.SourcesAzureCredentialsAsSourcesUpdateRequest(expandSourceAzureCredentials(name, credentials))
+	},
 }
 
-func resourceSourceAzureCredentialsCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	tflog.Info(ctx, "creating")
+func expandSourceAzureCredentials(name string, credentials map[string]string) *havaclient.SourcesAzureCredentials {
+	azureType := sourceTypeAzureCredentials
+	subId := credentials["subscription_id"]
+	tenantId := credentials["tenant_id"]
+	clientId := credentials["client_id"]
+	secretKey := credentials["secret_key"]
 
-	client := meta.(*havaclient.APIClient)
-
-	name := d.Get("name").(string)
-	azureType := "Azure::Credentials"
-	subId := d.Get("subscription_id").(string)
-	tenantId := d.Get("tenant_id").(string)
-	clientId := d.Get("client_id").(string)
-	secretKey := d.Get("secret_key").(string)
-
-	azureCredentialsSource := &havaclient.SourcesAzureCredentials{
+	return &havaclient.SourcesAzureCredentials{
 		Name:           &name,
 		Type:           &azureType,
 		SubscriptionId: &subId,
@@ -95,23 +59,36 @@ func resourceSourceAzureCredentialsCreate(ctx context.Context, d *schema.Resourc
 		ClientId:       &clientId,
 		SecretKey:      &secretKey,
 	}
+}
+
+func resourceHavaSourceAzureCredentials() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "A Source in Hava using an Azure service principal to authenticate to the Azure subscription that will be imported.",
 
-	body := havaclient.SourcesAzureCredentialsAsSourcesCreateRequest(azureCredentialsSource)
+		DeprecationMessage: "Use the hava_source resource with an azure_service_principal block instead",
 
-	req := client.SourcesApi.SourcesCreate(ctx)
+		CreateContext: resourceSourceAzureCredentialsCreate,
+		ReadContext:   resourceSourceAzureCredentialsRead,
+		UpdateContext: resourceSourceAzureCredentialsUpdate,
+		DeleteContext: resourceSourceAzureCredentialsDelete,
 
-	req = req.SourcesCreateRequest(body)
+		Importer: &schema.ResourceImporter{
+			StateContext: importSourceState(sourceKindAzureCredentials.Type, sourceKindAzureCredentials.InfoAttribute),
+		},
 
-	source, _, err := req.Execute()
+		Timeouts: sourceTimeouts(),
 
-	if err != nil {
-		return diag.FromErr(err)
+		Schema: sourceSchema(sourceKindAzureCredentials),
 	}
+}
+
+func resourceSourceAzureCredentialsCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	tflog.Info(ctx, "creating")
 
-	d.SetId(*source.Id)
-	d.Set("state", source.State)
+	client := meta.(*havaclient.APIClient)
 
-	return nil
+	return sdkCreateSource(ctx, d, client, sourceKindAzureCredentials)
 }
 
 func resourceSourceAzureCredentialsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
@@ -119,54 +96,14 @@ func resourceSourceAzureCredentialsRead(ctx context.Context, d *schema.ResourceD
 
 	client := meta.(*havaclient.APIClient)
 
-	req := client.SourcesApi.SourcesShow(ctx, d.Id())
-	source, res, err := req.Execute()
-
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	tflog.Info(ctx, res.Status)
-
-	d.Set("name", source.Name)
-	d.Set("state", source.State)
-
-	return nil
+	return sdkReadSource(ctx, d, client, sourceKindAzureCredentials)
 }
 
 func resourceSourceAzureCredentialsUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	tflog.Info(ctx, "updating")
 	client := meta.(*havaclient.APIClient)
 
-	name := d.Get("name").(string)
-	azureType := "Azure::Credentials"
-	subId := d.Get("subscription_id").(string)
-	tenantId := d.Get("tenant_id").(string)
-	clientId := d.Get("client_id").(string)
-	secretKey := d.Get("secret_key").(string)
-
-	azureCredentialsSource := &havaclient.SourcesAzureCredentials{
-		Name:           &name,
-		Type:           &azureType,
-		SubscriptionId: &subId,
-		TenantId:       &tenantId,
-		ClientId:       &clientId,
-		SecretKey:      &secretKey,
-	}
-
-	sourceUpdateRequest := havaclient.SourcesAzureCredentialsAsSourcesUpdateRequest(azureCredentialsSource)
-
-	req := client.SourcesApi.SourcesUpdate(ctx, d.Id())
-
-	req = req.SourcesUpdateRequest(sourceUpdateRequest)
-
-	_, _, err := req.Execute()
-
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	return nil
+	return sdkUpdateSource(ctx, d, client, sourceKindAzureCredentials)
 }
 
 func resourceSourceAzureCredentialsDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
@@ -174,13 +111,5 @@ func resourceSourceAzureCredentialsDelete(ctx context.Context, d *schema.Resourc
 
 	client := meta.(*havaclient.APIClient)
 
-	req := client.SourcesApi.SourcesDestroy(ctx, d.Id())
-
-	_, _, err := req.Execute()
-
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
-	return nil
+	return sdkDeleteSource(ctx, d, client, sourceKindAzureCredentials)
 }
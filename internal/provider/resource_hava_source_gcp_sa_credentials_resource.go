@@ -3,154 +3,549 @@ package provider
 import (
 	"context"
 	"fmt"
-
+	"maps"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	havaclient "github.com/teamhava/hava-sdk-go"
 )
 
-func resourceHavaSourceGCPCredentials() *schema.Resource {
-	return &schema.Resource{
-		// This description is used by the documentation generator and the language server.
-		Description: "A Source in Hava using an Google service account to authenticate to the GCP project that will be imported.",
-
-		CreateContext: resourceSourceGCPCredentialsCreate,
-		ReadContext:   resourceSourceGCPCredentialsRead,
-		UpdateContext: resourceSourceGCPCredentialsUpdate,
-		DeleteContext: resourceSourceGCPCredentialsDelete,
-
-		Schema: map[string]*schema.Schema{
-			"name": {
-				// This description is used by the documentation generator and the language server.
-				Description: "Display name of the source",
-				Type:        schema.TypeString,
-				Required:    true,
-			},
-			"encoded_file": {
-				Description: "Base64 encoded json Service Account credentials file content",
-				Sensitive:   true,
-				Type:        schema.TypeString,
-				Required:    true,
+var sourceKindGCPServiceAccount = sourceKind{
+	Type:        sourceTypeGCPServiceAccount,
+	Block:       "gcp_service_account",
+	Description: "Authenticate to the GCP project using a service account",
+
+	Attributes: []credentialAttribute{
+		{
+			Name:        "encoded_file",
+			Description: "Base64 encoded json Service Account credentials file content",
+			Secret:      true,
+			Format:      validateGCPServiceAccountKey,
+		},
+		{
+			Name:        "project_id",
+			Description: "ID of the GCP project the service account belongs to, read from the credentials file. Terraform warns while planning when new credentials belong to another project.",
+			Computed:    true,
+		},
+		{
+			Name:        "client_email",
+			Description: "Email address of the service account, read from the credentials file",
+			Computed:    true,
+		},
+		{
+			Name:        "private_key_id",
+			Description: "ID of the service account key, read from the credentials file",
+			Computed:    true,
+		},
+	},
+	CreateRequest: func(name string, credentials map[string]string) havaclient.SourcesCreateRequest {
+		return havaclient.SourcesGCPServiceAccountCredentialsAsSourcesCreateRequest(expandSourceGCPServiceAccount(name, credentials))
+	},
+	UpdateRequest: func(name string, credentials map[string]string) havaclient.SourcesUpdateRequest {
+		return havaclient.SourcesGCPServiceAccountCredentialsAsSourcesUpdateRequest(expandSourceGCPServiceAccount(name, credentials))
+	},
+	Metadata:         gcpServiceAccountMetadata,
+	MetadataWarnings: gcpServiceAccountMetadataWarnings,
+}
+
+// gcpServiceAccountMetadata returns the computed attributes read from the service account
+// credentials file, or nil when the file is invalid
+func gcpServiceAccountMetadata(credentials map[string]string) map[string]string {
+	key, err := decodeGCPServiceAccountKey(credentials["encoded_file"])
+
+	if err != nil {
+		// invalid files are rejected at plan time, there is nothing to read from them
+		return nil
+	}
+
+	return map[string]string{
+		"project_id":     key.ProjectID,
+		"client_email":   key.ClientEmail,
+		"private_key_id": key.PrivateKeyID,
+	}
+}
+
+// gcpServiceAccountMetadataWarnings warns when new credentials belong to another project than
+// the prior ones
+func gcpServiceAccountMetadataWarnings(prior map[string]string, metadata map[string]string) diag.Diagnostics {
+	old, new := prior["project_id"], metadata["project_id"]
+
+	if old == "" || new == "" || old == new {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Source switched to another GCP project",
+			Detail:   fmt.Sprintf("The new service account credentials belong to GCP project '%s', while the source imported GCP project '%s' until now. If this is not intended, restore the previous credentials.", new, old),
+		},
+	}
+}
+
+func expandSourceGCPServiceAccount(name string, credentials map[string]string) *havaclient.SourcesGCPServiceAccountCredentials {
+	gcpType := sourceTypeGCPServiceAccount
+	encodedFile := credentials["encoded_file"]
+
+	return &havaclient.SourcesGCPServiceAccountCredentials{
+		Name:        &name,
+		Type:        &gcpType,
+		EncodedFile: &encodedFile,
+	}
+}
+
+var (
+	_ resource.ResourceWithConfigure   = &sourceGCPCredentialsResource{}
+	_ resource.ResourceWithModifyPlan  = &sourceGCPCredentialsResource{}
+	_ resource.ResourceWithImportState = &sourceGCPCredentialsResource{}
+)
+
+// NewSourceGCPCredentialsResource returns the deprecated hava_source_gcp_sa_credentials_resource.
+// It has the schema of the SDKv2 resource it replaces, but is served by the framework provider
+// to warn about a change of project while planning, which CustomizeDiff can't.
+func NewSourceGCPCredentialsResource() resource.Resource {
+	return &sourceGCPCredentialsResource{}
+}
+
+type sourceGCPCredentialsResource struct {
+	client *havaclient.APIClient
+}
+
+type sourceGCPCredentialsResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	State              types.String   `tfsdk:"state"`
+	CredentialsVersion types.Int64    `tfsdk:"credentials_version"`
+	WaitForImport      types.Bool     `tfsdk:"wait_for_import"`
+	AdoptExisting      types.Bool     `tfsdk:"adopt_existing"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+
+	EncodedFile   types.String `tfsdk:"encoded_file"`
+	EncodedFileWO types.String `tfsdk:"encoded_file_wo"`
+	ProjectID     types.String `tfsdk:"project_id"`
+	ClientEmail   types.String `tfsdk:"client_email"`
+	PrivateKeyID  types.String `tfsdk:"private_key_id"`
+}
+
+// credentials returns the credentials configured in m, or nil when the credentials file isn't
+// known yet, which can only happen while planning. Write-only values are never part of the
+// plan, so they are read from config.
+func (m *sourceGCPCredentialsResourceModel) credentials(ctx context.Context, config tfsdk.Config) (map[string]string, fwdiag.Diagnostics) {
+	var diags fwdiag.Diagnostics
+
+	encodedFile := m.EncodedFile
+
+	if encodedFile.IsNull() {
+		diags.Append(config.GetAttribute(ctx, path.Root("encoded_file"+writeOnlySuffix), &encodedFile)...)
+	}
+
+	if encodedFile.IsUnknown() {
+		return nil, diags
+	}
+
+	return map[string]string{"encoded_file": encodedFile.ValueString()}, diags
+}
+
+// metadataAttributes returns the attributes read from the credentials file by name
+func (m *sourceGCPCredentialsResourceModel) metadataAttributes() map[string]*types.String {
+	return map[string]*types.String{
+		"project_id":     &m.ProjectID,
+		"client_email":   &m.ClientEmail,
+		"private_key_id": &m.PrivateKeyID,
+	}
+}
+
+// setMetadata sets the attributes read from the credentials file. They are unknown when
+// credentials is nil, because the file isn't known yet, and null when the file is invalid.
+func (m *sourceGCPCredentialsResourceModel) setMetadata(credentials map[string]string) {
+	var metadata map[string]string
+
+	if credentials != nil {
+		metadata = gcpServiceAccountMetadata(credentials)
+	}
+
+	for name, attribute := range m.metadataAttributes() {
+		if v, ok := metadata[name]; ok {
+			*attribute = types.StringValue(v)
+		} else if credentials == nil {
+			*attribute = types.StringUnknown()
+		} else {
+			*attribute = types.StringNull()
+		}
+	}
+}
+
+// metadata returns the known attributes read from the credentials file
+func (m *sourceGCPCredentialsResourceModel) metadata() map[string]string {
+	metadata := map[string]string{}
+
+	for name, attribute := range m.metadataAttributes() {
+		if !attribute.IsNull() && !attribute.IsUnknown() {
+			metadata[name] = attribute.ValueString()
+		}
+	}
+
+	return metadata
+}
+
+// sourceRequest returns the request for an operation on the source configured in m
+func (m *sourceGCPCredentialsResourceModel) sourceRequest(ctx context.Context, config tfsdk.Config, timeout time.Duration) (sourceRequest, fwdiag.Diagnostics) {
+	credentials, diags := m.credentials(ctx, config)
+
+	encodedFileAttribute := "encoded_file"
+
+	if m.EncodedFile.IsNull() {
+		encodedFileAttribute += writeOnlySuffix
+	}
+
+	return sourceRequest{
+		Kind:          sourceKindGCPServiceAccount,
+		ID:            m.ID.ValueString(),
+		Name:          m.Name.ValueString(),
+		Credentials:   credentials,
+		AdoptExisting: m.AdoptExisting.ValueBool(),
+		WaitForImport: m.WaitForImport.ValueBool(),
+		Timeout:       timeout,
+		AttributePath: func(field string) cty.Path {
+			switch field {
+			case "name":
+				return cty.GetAttrPath(field)
+			case "encoded_file":
+				return cty.GetAttrPath(encodedFileAttribute)
+			}
+
+			return nil
+		},
+	}, diags
+}
+
+func (r *sourceGCPCredentialsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_gcp_sa_credentials_resource"
+}
+
+func (r *sourceGCPCredentialsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		// optional like the implicit id attribute of SDKv2 resources, so that the schema is
+		// the same as before
+		"id": schema.StringAttribute{
+			MarkdownDescription: "ID of the source",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
 			},
-			"state": {
-				Description: "State of the Source",
-				Type:        schema.TypeString,
-				Computed:    true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: sourceNameDescription,
+			Required:            true,
+		},
+		"state": schema.StringAttribute{
+			MarkdownDescription: sourceStateDescription,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
 			},
 		},
-		CustomizeDiff: customdiff.All(
-
-			// if state is set to archived, it has been deleted outside of terraform and a new resource needs to be created
-			customdiff.ForceNewIf("state", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
-				state := d.Get("state").(string)
-
-				if state == "archived" {
-					tflog.Info(ctx, fmt.Sprintf("Source '%s' was deleted outside of terraform, a new resource will be created in it's place", d.Id()))
-					d.SetNewComputed("state")
-					return true
-				}
-				return false
+		"credentials_version": schema.Int64Attribute{
+			MarkdownDescription: sourceCredentialsVersionDescription,
+			Optional:            true,
+		},
+		"wait_for_import": schema.BoolAttribute{
+			MarkdownDescription: sourceWaitForImportDescription,
+			Optional:            true,
+		},
+		"adopt_existing": schema.BoolAttribute{
+			MarkdownDescription: sourceAdoptExistingDescription,
+			Optional:            true,
+		},
+		"deletion_protection": schema.BoolAttribute{
+			MarkdownDescription: sourceDeletionProtectionDescription,
+			Optional:            true,
+		},
+	}
+
+	maps.Copy(attributes, sourceKindGCPServiceAccount.frameworkAttributes())
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "A Source in Hava using an Google service account to authenticate to the GCP project that will be imported.",
+
+		DeprecationMessage: "Use the hava_source resource with a gcp_service_account block instead",
+
+		Attributes: attributes,
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
 			}),
-		),
+		},
 	}
 }
 
-func resourceSourceGCPCredentialsCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func (r *sourceGCPCredentialsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*havaclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *havaclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan plans the attributes read from the credentials file, warning when new
+// credentials belong to another project before anything is sent to Hava
+func (r *sourceGCPCredentialsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		// the source is destroyed
+		return
+	}
+
+	var plan sourceGCPCredentialsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credentials, diags := plan.credentials(ctx, req.Config)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.setMetadata(credentials)
+
+	if credentials != nil && !req.State.Raw.IsNull() {
+		var state sourceGCPCredentialsResourceModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(frameworkDiagnostics(gcpServiceAccountMetadataWarnings(state.metadata(), plan.metadata()))...)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *sourceGCPCredentialsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	tflog.Info(ctx, "creating")
 
-	client := meta.(*havaclient.APIClient)
+	var plan sourceGCPCredentialsResourceModel
 
-	name := d.Get("name").(string)
-	gcpType := "GCP::ServiceAccountCredentials"
-	encodedFile := d.Get("encoded_file").(string)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 
-	gcpCredentialsSource := &havaclient.SourcesGCPServiceAccountCredentials{
-		Name:           &name,
-		Type:           &gcpType,
-		EncodedFile: &encodedFile,
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	body := havaclient.SourcesGCPServiceAccountCredentialsAsSourcesCreateRequest(gcpCredentialsSource)
+	timeout, diags := plan.Timeouts.Create(ctx, sourceCreateTimeout)
 
-	req := client.SourcesApi.SourcesCreate(ctx)
+	resp.Diagnostics.Append(diags...)
 
-	req = req.SourcesCreateRequest(body)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	source, _, err := req.Execute()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	if err != nil {
-		return diag.FromErr(err)
+	sr, diags := plan.sourceRequest(ctx, req.Config, timeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source, sdkDiags := createSource(ctx, r.client, sr)
+
+	resp.Diagnostics.Append(frameworkDiagnostics(sdkDiags)...)
+
+	if source == nil {
+		return
 	}
 
-	d.SetId(*source.Id)
-	d.Set("state", source.State)
+	plan.ID = types.StringValue(source.GetId())
+	plan.State = types.StringValue(source.GetState())
+	plan.setMetadata(sr.Credentials)
 
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func resourceSourceGCPCredentialsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func (r *sourceGCPCredentialsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Info(ctx, "reading")
 
-	client := meta.(*havaclient.APIClient)
+	var state sourceGCPCredentialsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Read(ctx, sourceDefaultTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	req := client.SourcesApi.SourcesShow(ctx, d.Id())
-	source, res, err := req.Execute()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	source, err := readSource(ctx, r.client, state.ID.ValueString())
 
 	if err != nil {
-		return diag.FromErr(err)
+		sr := sourceRequest{
+			ID:      state.ID.ValueString(),
+			Name:    state.Name.ValueString(),
+			Timeout: timeout,
+		}
+
+		resp.Diagnostics.Append(frameworkDiagnostics(sr.diagnostics(sdkschema.TimeoutRead, err))...)
+		return
 	}
 
-	tflog.Info(ctx, res.Status)
+	if source == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
-	d.Set("name", source.Name)
-	d.Set("state", source.State)
+	state.Name = types.StringValue(source.GetName())
+	state.State = types.StringValue(source.GetState())
 
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-func resourceSourceGCPCredentialsUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func (r *sourceGCPCredentialsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	tflog.Info(ctx, "updating")
-	client := meta.(*havaclient.APIClient)
 
-	name := d.Get("name").(string)
-	gcpType := "GCP::ServiceAccountCredentials"
-	encodedFile := d.Get("encoded_file").(string)
+	var plan, state sourceGCPCredentialsResourceModel
 
-	gcpCredentialsSource := &havaclient.SourcesGCPServiceAccountCredentials{
-		Name:           &name,
-		Type:           &gcpType,
-		EncodedFile: &encodedFile,
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	sourceUpdateRequest := havaclient.SourcesGCPServiceAccountCredentialsAsSourcesUpdateRequest(gcpCredentialsSource)
+	timeout, diags := plan.Timeouts.Update(ctx, sourceDefaultTimeout)
 
-	req := client.SourcesApi.SourcesUpdate(ctx, d.Id())
+	resp.Diagnostics.Append(diags...)
 
-	req = req.SourcesUpdateRequest(sourceUpdateRequest)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	_, _, err := req.Execute()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	if err != nil {
-		return diag.FromErr(err)
+	plan.ID = state.ID
+
+	sr, diags := plan.sourceRequest(ctx, req.Config, timeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return nil
+	// changes to settings of the provider, such as deletion_protection, aren't sent to Hava
+	if !plan.Name.Equal(state.Name) || !plan.CredentialsVersion.Equal(state.CredentialsVersion) || !plan.EncodedFile.Equal(state.EncodedFile) {
+		resp.Diagnostics.Append(frameworkDiagnostics(updateSource(ctx, r.client, sr))...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	plan.setMetadata(sr.Credentials)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func resourceSourceGCPCredentialsDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func (r *sourceGCPCredentialsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Info(ctx, "deleting")
 
-	client := meta.(*havaclient.APIClient)
+	var state sourceGCPCredentialsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, sourceDefaultTimeout)
+
+	resp.Diagnostics.Append(diags...)
 
-	req := client.SourcesApi.SourcesDestroy(ctx, d.Id())
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	_, _, err := req.Execute()
+	sr := sourceRequest{
+		ID:      state.ID.ValueString(),
+		Name:    state.Name.ValueString(),
+		Timeout: timeout,
+	}
+
+	resp.Diagnostics.Append(frameworkDiagnostics(deleteSource(ctx, r.client, sr, state.DeletionProtection.ValueBool()))...)
+}
+
+func (r *sourceGCPCredentialsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Info(ctx, "importing")
+
+	source, res, err := r.client.SourcesApi.SourcesShow(ctx, req.ID).Execute()
 
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(frameworkAPIErrorDiagnostics(fmt.Sprintf("Unable to import source '%s'", req.ID), newAPIError(res, err))...)
+		return
+	}
+
+	if source.GetType() != sourceKindGCPServiceAccount.Type {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to import source '%s'", req.ID),
+			fmt.Sprintf("Source '%s' is of type '%s', but this resource manages sources of type '%s'.", req.ID, source.GetType(), sourceKindGCPServiceAccount.Type),
+		)
+		return
 	}
 
-	return nil
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), source.GetId())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), source.GetName())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("state"), source.GetState())...)
 }
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	havaclient "github.com/teamhava/hava-sdk-go"
+)
+
+// sourceSyncCreateTimeout is the default create timeout of hava_source_sync, which bounds
+// waiting for the sync to complete
+const sourceSyncCreateTimeout = sourceCreateTimeout
+
+var _ resource.ResourceWithConfigure = &sourceSyncResource{}
+
+func NewSourceSyncResource() resource.Resource {
+	return &sourceSyncResource{}
+}
+
+type sourceSyncResource struct {
+	client *havaclient.APIClient
+}
+
+type sourceSyncResourceModel struct {
+	ID                types.String   `tfsdk:"id"`
+	SourceID          types.String   `tfsdk:"source_id"`
+	Triggers          types.Map      `tfsdk:"triggers"`
+	WaitForCompletion types.Bool     `tfsdk:"wait_for_completion"`
+	State             types.String   `tfsdk:"state"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *sourceSyncResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_source_sync"
+}
+
+func (r *sourceSyncResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Triggers an on-demand sync of a Source in Hava, re-importing the resources of the remote account. A new sync is triggered every time the resource is replaced, e.g. when `triggers` change.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the sync job",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the source to sync",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary map of values that, when changed, will trigger a new sync of the source",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the sync to complete, failing if it fails. The wait is bounded by the `create` timeout. Defaults to `false`.",
+				Optional:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the sync job when it was last checked, `complete` once the sync has completed",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *sourceSyncResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*havaclient.APIClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *havaclient.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *sourceSyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "creating")
+
+	var plan sourceSyncResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, sourceSyncCreateTimeout)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sourceID := plan.SourceID.ValueString()
+
+	res, err := r.client.SourcesApi.SourcesSync(ctx, sourceID).Execute()
+
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(apiErrorDiagnostics(fmt.Sprintf("Unable to sync source '%s'", sourceID), newAPIError(res, err), func(field string) cty.Path {
+			return cty.GetAttrPath("source_id")
+		}))...)
+		return
+	}
+
+	jobID, err := jobIDFromLocation(res.Header.Get("Location"))
+
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Unable to sync source '%s'", sourceID), err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(jobID)
+	plan.State = types.StringValue("queued")
+
+	if plan.WaitForCompletion.ValueBool() {
+		state, err := waitForJob(ctx, r.client, jobID, timeout)
+
+		if state != "" {
+			plan.State = types.StringValue(state)
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Sync of source '%s' did not complete", sourceID), err.Error())
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *sourceSyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "reading")
+
+	var state sourceSyncResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sourceID := state.SourceID.ValueString()
+
+	// the sync itself is a one-off, so only check that the source still exists
+	_, res, err := r.client.SourcesApi.SourcesShow(ctx, sourceID).Execute()
+
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		tflog.Warn(ctx, fmt.Sprintf("Source '%s' no longer exists in Hava, removing its sync from state", sourceID))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.Append(frameworkAPIErrorDiagnostics(fmt.Sprintf("Unable to read source '%s'", sourceID), newAPIError(res, err))...)
+	}
+}
+
+func (r *sourceSyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// only wait_for_completion and the timeouts can change without replacing the sync, and
+	// they only affect how the sync is created
+	var plan sourceSyncResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *sourceSyncResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// a sync can't be undone, removing it from state is all there is to do
+}
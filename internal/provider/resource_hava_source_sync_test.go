@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSourceSyncResource_create(t *testing.T) {
+	sourceImportPollInterval = time.Millisecond
+	t.Cleanup(func() { sourceImportPollInterval = 10 * time.Second })
+
+	cases := map[string]struct {
+		jobStates []string
+		wantState string
+		wantErr   string
+	}{
+		"completes": {jobStates: []string{"queued", "active", jobStateComplete}, wantState: jobStateComplete},
+		"fails":     {jobStates: []string{"queued", "failed"}, wantState: "failed", wantErr: "job 'job1' failed"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var synced, polls int32
+
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/sources/abc/sync":
+					atomic.AddInt32(&synced, 1)
+					w.Header().Set("Location", "https://api.hava.io/jobs/job1")
+					w.WriteHeader(http.StatusAccepted)
+				case r.Method == http.MethodGet && r.URL.Path == "/jobs/job1":
+					state := tc.jobStates[min(int(atomic.AddInt32(&polls, 1))-1, len(tc.jobStates)-1)]
+
+					if state == jobStateComplete {
+						http.Redirect(w, r, "/sources/abc", http.StatusSeeOther)
+						return
+					}
+
+					w.Header().Set("Content-Type", "application/json")
+					fmt.Fprintf(w, `{"id":"job1","state":%q}`, state)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			r := &sourceSyncResource{client: client}
+			s := testResourceSchema(t, r)
+			plan := testResourceValue(t, s, map[string]any{
+				"source_id":           "abc",
+				"wait_for_completion": true,
+			})
+
+			resp := resource.CreateResponse{
+				State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)},
+			}
+
+			r.Create(context.Background(), resource.CreateRequest{
+				Config: tfsdk.Config{Schema: s, Raw: plan},
+				Plan:   tfsdk.Plan{Schema: s, Raw: plan},
+			}, &resp)
+
+			diags := resp.Diagnostics
+
+			if tc.wantErr == "" && diags.HasError() {
+				t.Fatalf("unexpected error: %+v", diags)
+			}
+
+			if tc.wantErr != "" && (!diags.HasError() || !strings.Contains(diags[0].Detail(), tc.wantErr)) {
+				t.Fatalf("expected an error containing %q, got: %+v", tc.wantErr, diags)
+			}
+
+			if synced != 1 {
+				t.Errorf("expected the source to be synced once, got %d", synced)
+			}
+
+			var got sourceSyncResourceModel
+
+			if diags := resp.State.Get(context.Background(), &got); diags.HasError() {
+				t.Fatalf("unexpected error: %+v", diags)
+			}
+
+			if got.ID.ValueString() != "job1" {
+				t.Errorf("expected the ID to be the job ID, got %q", got.ID.ValueString())
+			}
+
+			if got.State.ValueString() != tc.wantState {
+				t.Errorf("expected state %q, got %q", tc.wantState, got.State.ValueString())
+			}
+		})
+	}
+}
+
+func TestJobIDFromLocation(t *testing.T) {
+	for location, want := range map[string]string{
+		"https://api.hava.io/jobs/046b6c7f-0b8a-43b9-b35d-6489e6daee91": "046b6c7f-0b8a-43b9-b35d-6489e6daee91",
+		"/jobs/046b6c7f-0b8a-43b9-b35d-6489e6daee91/":                   "046b6c7f-0b8a-43b9-b35d-6489e6daee91",
+		"046b6c7f-0b8a-43b9-b35d-6489e6daee91":                          "046b6c7f-0b8a-43b9-b35d-6489e6daee91",
+	} {
+		got, err := jobIDFromLocation(location)
+
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", location, err)
+		} else if got != want {
+			t.Errorf("expected %q for %q, got %q", want, location, got)
+		}
+	}
+
+	if _, err := jobIDFromLocation(""); err == nil {
+		t.Error("expected an error for an empty location")
+	}
+}
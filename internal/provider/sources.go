@@ -0,0 +1,759 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	havaclient "github.com/teamhava/hava-sdk-go"
+)
+
+// Source types as reported by the Hava API
+const (
+	sourceTypeAWSCAR            = "AWS::CrossAccountRole"
+	sourceTypeAWSKey            = "AWS::Keys"
+	sourceTypeAzureCredentials  = "Azure::Credentials"
+	sourceTypeGCPServiceAccount = "GCP::ServiceAccountCredentials"
+)
+
+// sourceTypes lists all source types supported by the provider
+var sourceTypes = []string{
+	sourceTypeAWSCAR,
+	sourceTypeAWSKey,
+	sourceTypeAzureCredentials,
+	sourceTypeGCPServiceAccount,
+}
+
+// States of a source that is still being imported
+var sourceImportPendingStates = []string{"queued", "connecting", "importing", "enriching"}
+
+// States of a source whose import failed
+var sourceImportFailedStates = []string{"error", "invalid"}
+
+// sourceImportPollInterval is how often the state of a source is checked while waiting for
+// its import to finish
+var sourceImportPollInterval = 10 * time.Second
+
+// Default timeouts of the source resources. Creating a source can include waiting for its
+// first import, every other operation is a single request.
+const (
+	sourceCreateTimeout  = 30 * time.Minute
+	sourceDefaultTimeout = 5 * time.Minute
+)
+
+// Descriptions of the attributes shared by all source resources
+const (
+	sourceNameDescription               = "Display name of the source"
+	sourceStateDescription              = "State of the Source"
+	sourceCredentialsVersionDescription = "Version of the write-only credentials. Terraform never knows if the values of write-only attributes changed, so change this, e.g. by incrementing it, to send new values to Hava when rotating credentials."
+	sourceWaitForImportDescription      = "Wait for the first import of a new source to finish before the source is considered created, failing if the import fails. The wait is bounded by the `create` timeout. Defaults to `false`."
+	sourceAdoptExistingDescription      = "When creating the source, take over a source of the same type that already exists in Hava with the same credentials, such as the same role ARN or access key, or otherwise the same name, instead of creating a new one. A source with the same name but other credentials is not adopted, and neither is an Azure or GCP source with the same name, as Hava doesn't return their credentials to compare. Import those with terraform import instead. The adopted source is updated with the configured name and credentials. Defaults to `false`."
+	sourceDeletionProtectionDescription = "Refuse to delete the source, e.g. when its resource is removed from the configuration or has to be replaced, to keep its diagram history. Set this to `false` and apply before deleting the source. Defaults to `false`."
+)
+
+// sourceTimeouts returns the default timeouts of the SDKv2 source resources
+func sourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(sourceCreateTimeout),
+		Read:   schema.DefaultTimeout(sourceDefaultTimeout),
+		Update: schema.DefaultTimeout(sourceDefaultTimeout),
+		Delete: schema.DefaultTimeout(sourceDefaultTimeout),
+	}
+}
+
+// credentialAttribute is an attribute of the credentials a kind of source is configured with
+type credentialAttribute struct {
+	Name        string
+	Description string
+	// Secret attributes are optional and have a write-only counterpart, which Terraform 1.11
+	// and later never store in state. Exactly one of the two must be set.
+	Secret bool
+	// Computed attributes are derived from the other credentials by the Metadata function of
+	// the kind, and are the only attributes that aren't sensitive
+	Computed bool
+	// Format checks the value before it is sent to Hava, if set
+	Format credentialFormat
+}
+
+// sourceKind describes one type of Hava source: the credential attributes it is configured
+// with, and how those attributes are sent to the Hava API. The same kind backs both the
+// deprecated SDKv2 source resources, where the credentials are top level attributes, and the
+// nested credential blocks of the hava_source resource served by the framework provider.
+type sourceKind struct {
+	// Type of the source in the Hava API, e.g. AWS::CrossAccountRole
+	Type string
+	// Block is the name of the nested block configuring this kind of source in hava_source
+	Block string
+	// Description of the nested block
+	Description string
+	// InfoAttribute is the credential attribute the Hava API returns as the source info, if any
+	InfoAttribute string
+
+	Attributes []credentialAttribute
+
+	// CreateRequest and UpdateRequest build the request bodies from the name of the source and
+	// its credentials, keyed by attribute name with write-only values in place of their secret
+	// counterparts
+	CreateRequest func(name string, credentials map[string]string) havaclient.SourcesCreateRequest
+	UpdateRequest func(name string, credentials map[string]string) havaclient.SourcesUpdateRequest
+
+	// Metadata returns the computed attributes derived from the credentials, or nil when they
+	// can't be derived. It is nil for kinds without computed attributes.
+	Metadata func(credentials map[string]string) map[string]string
+	// MetadataWarnings returns warnings about how the computed attributes change from prior to
+	// metadata, if any. It is nil for kinds that don't warn.
+	MetadataWarnings func(prior map[string]string, metadata map[string]string) diag.Diagnostics
+}
+
+// sdkSchema returns the credential attributes of the kind as top level attributes of an SDKv2
+// schema
+func (k sourceKind) sdkSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{}
+
+	for _, a := range k.Attributes {
+		var validate schema.SchemaValidateDiagFunc
+
+		if a.Format != nil {
+			validate = a.Format.sdkValidator()
+		}
+
+		switch {
+		case a.Computed:
+			s[a.Name] = &schema.Schema{
+				Description: a.Description,
+				Type:        schema.TypeString,
+				Computed:    true,
+			}
+		case a.Secret:
+			maps.Copy(s, secretCredentialSchema(a.Name, a.Description, validate))
+		default:
+			s[a.Name] = &schema.Schema{
+				Description:      a.Description,
+				Sensitive:        true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validate,
+			}
+		}
+	}
+
+	return s
+}
+
+// sourceSchema returns the SDKv2 schema of a deprecated source resource, made up of the
+// attributes shared by all source resources and the credential attributes of kind
+func sourceSchema(kind sourceKind) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"name": {
+			// This description is used by the documentation generator and the language server.
+			Description: sourceNameDescription,
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"state": {
+			Description: sourceStateDescription,
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"credentials_version": {
+			Description: sourceCredentialsVersionDescription,
+			Type:        schema.TypeInt,
+			Optional:    true,
+		},
+		"wait_for_import": {
+			Description: sourceWaitForImportDescription,
+			Type:        schema.TypeBool,
+			Optional:    true,
+		},
+		"adopt_existing": {
+			Description: sourceAdoptExistingDescription,
+			Type:        schema.TypeBool,
+			Optional:    true,
+		},
+		"deletion_protection": {
+			Description: sourceDeletionProtectionDescription,
+			Type:        schema.TypeBool,
+			Optional:    true,
+		},
+	}
+
+	maps.Copy(s, kind.sdkSchema())
+
+	return s
+}
+
+// writeOnlySuffix is appended to the name of a secret credential attribute to get the name of
+// its write-only counterpart
+const writeOnlySuffix = "_wo"
+
+// secretCredentialDescription returns the description of a secret credential attribute
+func secretCredentialDescription(attribute string, description string) string {
+	return fmt.Sprintf("%s. The value is stored in state, use `%s%s` to keep it out of state.", description, attribute, writeOnlySuffix)
+}
+
+// writeOnlyCredentialDescription returns the description of the write-only counterpart of a
+// secret credential attribute
+func writeOnlyCredentialDescription(description string) string {
+	return fmt.Sprintf("%s. The value is write-only and never stored in state, which requires Terraform 1.11 or later. Change `credentials_version` to send a new value to Hava.", description)
+}
+
+// secretCredentialSchema returns the SDKv2 schema of a secret credential attribute and of its
+// write-only counterpart. Exactly one of the two must be set, and sourceCredential reads
+// whichever it is. Both are checked by validate, if it isn't nil.
+func secretCredentialSchema(attribute string, description string, validate schema.SchemaValidateDiagFunc) map[string]*schema.Schema {
+	exactlyOneOf := []string{attribute, attribute + writeOnlySuffix}
+
+	return map[string]*schema.Schema{
+		attribute: {
+			Description:      secretCredentialDescription(attribute, description),
+			Sensitive:        true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			ExactlyOneOf:     exactlyOneOf,
+			ValidateDiagFunc: validate,
+		},
+		attribute + writeOnlySuffix: {
+			Description:      writeOnlyCredentialDescription(description),
+			Sensitive:        true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			WriteOnly:        true,
+			ExactlyOneOf:     exactlyOneOf,
+			ValidateDiagFunc: validate,
+		},
+	}
+}
+
+// sourceCredential returns the value of a secret credential attribute, or of its write-only
+// counterpart when that is set instead. Write-only values are only available in the
+// configuration, so this must only be called while creating or updating a source.
+func sourceCredential(d *schema.ResourceData, attribute string) string {
+	if v := d.Get(attribute).(string); v != "" {
+		return v
+	}
+
+	return configString(d.GetRawConfig(), attribute+writeOnlySuffix)
+}
+
+// sdkSourceCredentials returns the credentials of kind configured in d
+func sdkSourceCredentials(d *schema.ResourceData, kind sourceKind) map[string]string {
+	credentials := map[string]string{}
+
+	for _, a := range kind.Attributes {
+		if !a.Computed {
+			credentials[a.Name] = sourceCredential(d, a.Name)
+		}
+	}
+
+	return credentials
+}
+
+// configString returns the string at key in config, where key is in the dotted format used by
+// schema.ResourceData, e.g. aws_access_key.0.secret_key, or an empty string if it isn't set
+func configString(config cty.Value, key string) string {
+	if config.IsNull() || !config.IsKnown() {
+		return ""
+	}
+
+	v, err := attributePath(key).Apply(config)
+
+	if err != nil || v.IsNull() || !v.IsKnown() || !v.Type().Equals(cty.String) {
+		return ""
+	}
+
+	return v.AsString()
+}
+
+// attributePath converts a key in the dotted format used by schema.ResourceData, e.g.
+// aws_access_key.0.secret_key, to a cty.Path
+func attributePath(key string) cty.Path {
+	var p cty.Path
+
+	for _, step := range strings.Split(key, ".") {
+		if i, err := strconv.Atoi(step); err == nil {
+			p = p.IndexInt(i)
+		} else {
+			p = p.GetAttr(step)
+		}
+	}
+
+	return p
+}
+
+// sourceRequest is an operation on a source, independent of whether the resource it is
+// configured by is served by the SDKv2 or the framework provider
+type sourceRequest struct {
+	Kind sourceKind
+	// ID of the source, empty while creating it
+	ID          string
+	Name        string
+	Credentials map[string]string
+
+	AdoptExisting bool
+	WaitForImport bool
+
+	// Timeout of the operation, which bounds the context it runs with
+	Timeout time.Duration
+	// AttributePath returns the path of the attribute a field of the source in the Hava API
+	// maps to, or nil if there is none
+	AttributePath func(field string) cty.Path
+}
+
+// sdkSourceRequest returns the request for an operation on the source backing d, where
+// operation is one of the timeout keys, e.g. schema.TimeoutCreate
+func sdkSourceRequest(d *schema.ResourceData, kind sourceKind, operation string) sourceRequest {
+	return sourceRequest{
+		Kind:          kind,
+		ID:            d.Id(),
+		Name:          d.Get("name").(string),
+		Credentials:   sdkSourceCredentials(d, kind),
+		AdoptExisting: d.Get("adopt_existing").(bool),
+		WaitForImport: d.Get("wait_for_import").(bool),
+		Timeout:       d.Timeout(operation),
+		AttributePath: func(field string) cty.Path {
+			return sourceAttributePath(d, field)
+		},
+	}
+}
+
+// sourceAttributePath returns the path of the attribute of d that a field of a source in the
+// Hava API maps to, or nil if there is none. Secrets may be set through their write-only
+// counterparts.
+func sourceAttributePath(d *schema.ResourceData, field string) cty.Path {
+	if d.Get(field) == nil {
+		// not an attribute of this resource
+		return nil
+	}
+
+	if d.Get(field) == "" && d.Get(field+writeOnlySuffix) != nil {
+		field += writeOnlySuffix
+	}
+
+	return cty.GetAttrPath(field)
+}
+
+// diagnostics returns the diagnostics for an error from the given operation on the source,
+// where operation is one of the timeout keys, e.g. schema.TimeoutCreate. Terraform bounds
+// every operation by its timeout, so when the deadline is exceeded the diagnostic says which
+// operation timed out, instead of just "context deadline exceeded".
+func (r sourceRequest) diagnostics(operation string, err error) diag.Diagnostics {
+	source := fmt.Sprintf("'%s'", r.Name)
+
+	if r.ID != "" {
+		source = fmt.Sprintf("'%s' (%s)", r.Name, r.ID)
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Timed out waiting to %s source %s", operation, source),
+				Detail:   fmt.Sprintf("The %s operation on source %s did not finish within the %s timeout of %s. If the Hava API is slow to respond, increase the %s timeout in the timeouts block of the resource.\n\n%s", operation, source, operation, r.Timeout, operation, err),
+			},
+		}
+	}
+
+	return apiErrorDiagnostics(fmt.Sprintf("Unable to %s source %s", operation, source), err, r.AttributePath)
+}
+
+// importSourceState returns an import function that looks up the source by ID and
+// makes sure it is of the type managed by the resource being imported.
+//
+// The Hava API never returns credentials, so only non-secret attributes are set. The
+// info attribute is the attribute that the source's info field maps to for this source
+// type, e.g. the role ARN for cross account role sources, or empty if there is none.
+func importSourceState(sourceType string, infoAttribute string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+		tflog.Info(ctx, "importing")
+
+		client := meta.(*havaclient.APIClient)
+
+		source, res, err := client.SourcesApi.SourcesShow(ctx, d.Id()).Execute()
+
+		if err != nil {
+			return nil, apiErrorAsError(fmt.Sprintf("Unable to import source '%s'", d.Id()), newAPIError(res, err))
+		}
+
+		if source.GetType() != sourceType {
+			return nil, fmt.Errorf("source '%s' is of type '%s', but this resource manages sources of type '%s'", d.Id(), source.GetType(), sourceType)
+		}
+
+		d.Set("name", source.Name)
+		d.Set("state", source.State)
+
+		if infoAttribute != "" && source.Info != nil {
+			d.Set(infoAttribute, source.Info)
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// readSource fetches the source with the given ID from Hava. Sources that no longer exist,
+// or that have been archived outside of terraform, are reported by returning nil, so that
+// the caller removes them from state and terraform plans to create them again.
+func readSource(ctx context.Context, client *havaclient.APIClient, id string) (*havaclient.Source, error) {
+	source, res, err := client.SourcesApi.SourcesShow(ctx, id).Execute()
+
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		tflog.Warn(ctx, fmt.Sprintf("Source '%s' no longer exists in Hava, removing it from state", id))
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, newAPIError(res, err)
+	}
+
+	tflog.Info(ctx, res.Status)
+
+	if source.GetState() == "archived" {
+		tflog.Warn(ctx, fmt.Sprintf("Source '%s' was archived outside of terraform, removing it from state", id))
+		return nil, nil
+	}
+
+	return source, nil
+}
+
+// listSources returns all sources in the Hava account, following pagination until the
+// last page has been read.
+func listSources(ctx context.Context, client *havaclient.APIClient) ([]havaclient.Source, error) {
+	var sources []havaclient.Source
+
+	req := client.SourcesApi.SourcesIndex(ctx)
+
+	for {
+		page, res, err := req.Execute()
+
+		if err != nil {
+			return nil, newAPIError(res, err)
+		}
+
+		sources = append(sources, page.Results...)
+
+		if page.GetNextPageToken() == "" {
+			return sources, nil
+		}
+
+		req = client.SourcesApi.SourcesIndex(ctx).Token(page.GetNextPageToken())
+	}
+}
+
+// sourceDisplayName returns the name of source as shown in Hava, which is its display name,
+// or its name when it has no display name
+func sourceDisplayName(source havaclient.Source) string {
+	if name := source.GetDisplayName(); name != "" {
+		return name
+	}
+
+	return source.GetName()
+}
+
+// createSource creates the source of r, or adopts an existing matching source when
+// AdoptExisting is set, and waits for its import when WaitForImport is set. The source is
+// returned whenever it exists in Hava, even along with errors, so that it can be stored.
+func createSource(ctx context.Context, client *havaclient.APIClient, r sourceRequest) (*havaclient.Source, diag.Diagnostics) {
+	var source *havaclient.Source
+	var diags diag.Diagnostics
+
+	if r.AdoptExisting {
+		source, diags = adoptSource(ctx, client, r)
+
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	if source == nil {
+		body := r.Kind.CreateRequest(r.Name, r.Credentials)
+
+		logSourceRequest(ctx, "creating source", body)
+
+		req := client.SourcesApi.SourcesCreate(ctx)
+
+		req = req.SourcesCreateRequest(body)
+
+		created, res, err := req.Execute()
+
+		if res != nil {
+			tflog.Info(ctx, res.Status)
+		}
+
+		if err != nil {
+			tflog.Error(ctx, fmt.Sprintf("%+v", err))
+			return nil, r.diagnostics(schema.TimeoutCreate, newAPIError(res, err))
+		}
+
+		source = created
+
+		tflog.Trace(ctx, "created a resource")
+	}
+
+	if !r.WaitForImport {
+		return source, diags
+	}
+
+	r.ID = source.GetId()
+
+	imported, err := waitForSourceImport(ctx, client, r.ID, r.Timeout)
+
+	if imported != nil {
+		source = imported
+	}
+
+	if err != nil {
+		return source, append(diags, r.diagnostics(schema.TimeoutCreate, err)...)
+	}
+
+	return source, diags
+}
+
+// adoptSource looks for a source of the kind of r that already exists in Hava with the same
+// credentials, or failing that the same name, and takes it over by updating it with the
+// configured name and credentials. It returns nil when there is nothing to adopt.
+func adoptSource(ctx context.Context, client *havaclient.APIClient, r sourceRequest) (*havaclient.Source, diag.Diagnostics) {
+	sources, err := listSources(ctx, client)
+
+	if err != nil {
+		return nil, r.diagnostics(schema.TimeoutCreate, err)
+	}
+
+	kind := r.Kind
+
+	info := ""
+
+	if kind.InfoAttribute != "" {
+		info = r.Credentials[kind.InfoAttribute]
+	}
+
+	var byCredentials, byName []havaclient.Source
+
+	for _, source := range sources {
+		if source.GetType() != kind.Type || source.GetState() == "archived" {
+			continue
+		}
+
+		if info != "" && source.GetInfo() == info {
+			byCredentials = append(byCredentials, source)
+		} else if source.GetName() == r.Name {
+			byName = append(byName, source)
+		}
+	}
+
+	matches, match := byCredentials, kind.InfoAttribute
+
+	if len(matches) == 0 {
+		matches, match = byName, "name"
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		ids := make([]string, 0, len(matches))
+
+		for _, source := range matches {
+			ids = append(ids, source.GetId())
+		}
+
+		return nil, diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to adopt an existing source for '%s'", r.Name),
+				Detail:   fmt.Sprintf("adopt_existing is set, but %d sources of type %s in Hava have the same %s: %s. Import the one to manage with terraform import instead.", len(matches), kind.Type, match, strings.Join(ids, ", ")),
+			},
+		}
+	}
+
+	existing := matches[0]
+
+	// a source with the same name, but other credentials, gives access to another cloud account,
+	// which updating it with the configured credentials would silently take away. Hava doesn't
+	// return any credentials of some types of sources, so those can't be told apart by name.
+	if match == "name" && kind.InfoAttribute == "" {
+		return nil, diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to adopt an existing source for '%s'", r.Name),
+				Detail:   fmt.Sprintf("adopt_existing is set, but source '%s' (%s) in Hava has the same name, and Hava doesn't return the credentials of %s sources to check they are the same. Import it with terraform import to manage it, or rename the source.", existing.GetName(), existing.GetId(), kind.Type),
+			},
+		}
+	}
+
+	if match == "name" && info != "" && existing.GetInfo() != "" {
+		return nil, diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Unable to adopt an existing source for '%s'", r.Name),
+				Detail:   fmt.Sprintf("adopt_existing is set, but source '%s' (%s) in Hava has the same name and another %s. Import it with terraform import to manage it with its current credentials, or rename the source.", existing.GetName(), existing.GetId(), kind.InfoAttribute),
+			},
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("adopting source '%s' with the same %s", existing.GetId(), match))
+
+	body := kind.UpdateRequest(r.Name, r.Credentials)
+
+	logSourceRequest(ctx, "updating adopted source", body)
+
+	source, res, err := client.SourcesApi.SourcesUpdate(ctx, existing.GetId()).SourcesUpdateRequest(body).Execute()
+
+	if err != nil {
+		// nothing is returned, so a failed adoption never ends up in state, where terraform
+		// would replace, and with that delete, the source on the next apply
+		return nil, r.diagnostics(schema.TimeoutCreate, newAPIError(res, err))
+	}
+
+	source.SetId(existing.GetId())
+
+	return source, diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Adopted existing source '%s'", existing.GetName()),
+			Detail:   fmt.Sprintf("adopt_existing is set and source '%s' (%s) already existed in Hava with the same %s, so it was taken over and updated with the configured name and credentials instead of creating a new source. Terraform now manages it, so destroying this resource deletes the source from Hava.", existing.GetName(), existing.GetId(), match),
+		},
+	}
+}
+
+// waitForSourceImport polls the source until its import has finished, returning an error
+// if the import fails or doesn't finish within timeout
+func waitForSourceImport(ctx context.Context, client *havaclient.APIClient, id string, timeout time.Duration) (*havaclient.Source, error) {
+	tflog.Info(ctx, fmt.Sprintf("waiting for the import of source '%s' to finish", id))
+
+	conf := &retry.StateChangeConf{
+		Pending: sourceImportPendingStates,
+		Target:  []string{"active"},
+		Refresh: func() (any, string, error) {
+			source, _, err := client.SourcesApi.SourcesShow(ctx, id).Execute()
+
+			if err != nil {
+				return nil, "", err
+			}
+
+			return source, source.GetState(), nil
+		},
+		Timeout:      timeout,
+		PollInterval: sourceImportPollInterval,
+	}
+
+	result, err := conf.WaitForStateContext(ctx)
+
+	source, _ := result.(*havaclient.Source)
+
+	if err == nil {
+		return source, nil
+	}
+
+	if source != nil && slices.Contains(sourceImportFailedStates, source.GetState()) {
+		return source, fmt.Errorf("import of source '%s' (%s) failed, Hava reported the source as '%s': check the credentials of the source and its details in Hava", source.GetName(), id, source.GetState())
+	}
+
+	return source, fmt.Errorf("error waiting for the import of source '%s' to finish: %w", id, err)
+}
+
+// updateSource sends the name and credentials of r as an update to the source
+func updateSource(ctx context.Context, client *havaclient.APIClient, r sourceRequest) diag.Diagnostics {
+	body := r.Kind.UpdateRequest(r.Name, r.Credentials)
+
+	logSourceRequest(ctx, "updating source", body)
+
+	req := client.SourcesApi.SourcesUpdate(ctx, r.ID)
+
+	req = req.SourcesUpdateRequest(body)
+
+	_, res, err := req.Execute()
+
+	if err != nil {
+		return r.diagnostics(schema.TimeoutUpdate, newAPIError(res, err))
+	}
+
+	return nil
+}
+
+// deleteSource deletes the source of r, unless it is protected from deletion
+func deleteSource(ctx context.Context, client *havaclient.APIClient, r sourceRequest, protected bool) diag.Diagnostics {
+	if protected {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Source '%s' is protected from deletion", r.Name),
+				Detail:        fmt.Sprintf("deletion_protection is set on source '%s'. To delete it, set deletion_protection to false and apply that change first, then delete the source in a separate apply.", r.ID),
+				AttributePath: cty.GetAttrPath("deletion_protection"),
+			},
+		}
+	}
+
+	req := client.SourcesApi.SourcesDestroy(ctx, r.ID)
+
+	_, res, err := req.Execute()
+
+	if res != nil && res.StatusCode == http.StatusNotFound {
+		// already gone, e.g. because an earlier attempt of a retried request deleted it
+		tflog.Warn(ctx, fmt.Sprintf("Source '%s' no longer exists in Hava", r.ID))
+		return nil
+	}
+
+	if err != nil {
+		return r.diagnostics(schema.TimeoutDelete, newAPIError(res, err))
+	}
+
+	return nil
+}
+
+// sdkCreateSource creates the source of the given kind backing d, storing its ID and state
+func sdkCreateSource(ctx context.Context, d *schema.ResourceData, client *havaclient.APIClient, kind sourceKind) diag.Diagnostics {
+	source, diags := createSource(ctx, client, sdkSourceRequest(d, kind, schema.TimeoutCreate))
+
+	if source != nil {
+		d.SetId(source.GetId())
+		d.Set("state", source.State)
+	}
+
+	return diags
+}
+
+// sdkReadSource refreshes the name and state of the source backing d, removing it from state
+// when it no longer exists
+func sdkReadSource(ctx context.Context, d *schema.ResourceData, client *havaclient.APIClient, kind sourceKind) diag.Diagnostics {
+	source, err := readSource(ctx, client, d.Id())
+
+	if err != nil {
+		return sdkSourceRequest(d, kind, schema.TimeoutRead).diagnostics(schema.TimeoutRead, err)
+	}
+
+	if source == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", source.Name)
+	d.Set("state", source.State)
+
+	return nil
+}
+
+// sdkUpdateSource sends the name and credentials configured in d as an update to the source
+func sdkUpdateSource(ctx context.Context, d *schema.ResourceData, client *havaclient.APIClient, kind sourceKind) diag.Diagnostics {
+	if !d.HasChangesExcept("wait_for_import", "deletion_protection", "adopt_existing") {
+		// only settings of the provider changed, there is nothing to send to Hava
+		return nil
+	}
+
+	return updateSource(ctx, client, sdkSourceRequest(d, kind, schema.TimeoutUpdate))
+}
+
+// sdkDeleteSource deletes the source of the given kind backing d
+func sdkDeleteSource(ctx context.Context, d *schema.ResourceData, client *havaclient.APIClient, kind sourceKind) diag.Diagnostics {
+	return deleteSource(ctx, client, sdkSourceRequest(d, kind, schema.TimeoutDelete), d.Get("deletion_protection").(bool))
+}
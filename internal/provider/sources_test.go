@@ -0,0 +1,491 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	havaclient "github.com/teamhava/hava-sdk-go"
+)
+
+// newTestClient returns a Hava API client that sends all requests to handler
+func newTestClient(t *testing.T, handler http.Handler) *havaclient.APIClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := havaclient.NewConfiguration()
+	cfg.Servers = havaclient.ServerConfigurations{{URL: server.URL}}
+	cfg.HTTPClient = server.Client()
+
+	return havaclient.NewAPIClient(cfg)
+}
+
+func testSourceHandler(sourceType string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":"abc","type":%q,"name":"imported","state":"active","info":"arn:aws:iam::123456789012:role/Hava"}`, sourceType)
+	})
+}
+
+func TestImportSourceState(t *testing.T) {
+	client := newTestClient(t, testSourceHandler(sourceTypeAWSCAR))
+
+	d := schema.TestResourceDataRaw(t, resourceHavaSourceAWSCAR().Schema, map[string]any{})
+	d.SetId("abc")
+
+	imported, err := importSourceState(sourceTypeAWSCAR, "role_arn")(context.Background(), d, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(imported) != 1 {
+		t.Fatalf("expected 1 imported resource, got %d", len(imported))
+	}
+
+	for attr, want := range map[string]string{
+		"name":     "imported",
+		"state":    "active",
+		"role_arn": "arn:aws:iam::123456789012:role/Hava",
+	} {
+		if got := imported[0].Get(attr).(string); got != want {
+			t.Errorf("expected %s to be %q, got %q", attr, want, got)
+		}
+	}
+}
+
+func TestImportSourceState_wrongType(t *testing.T) {
+	client := newTestClient(t, testSourceHandler(sourceTypeAWSKey))
+
+	d := schema.TestResourceDataRaw(t, resourceHavaSourceAWSCAR().Schema, map[string]any{})
+	d.SetId("abc")
+
+	_, err := importSourceState(sourceTypeAWSCAR, "role_arn")(context.Background(), d, client)
+	if err == nil {
+		t.Fatal("expected an error when importing a source of another type")
+	}
+
+	if !strings.Contains(err.Error(), sourceTypeAWSKey) {
+		t.Errorf("expected error to mention the remote source type, got: %s", err)
+	}
+}
+
+func TestImportSourceState_unauthorized(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	d := schema.TestResourceDataRaw(t, resourceHavaSourceAWSCAR().Schema, map[string]any{})
+	d.SetId("abc")
+
+	_, err := importSourceState(sourceTypeAWSCAR, "role_arn")(context.Background(), d, client)
+	if err == nil {
+		t.Fatal("expected an error when the API token is rejected")
+	}
+
+	if !strings.Contains(err.Error(), "HAVA_TOKEN") {
+		t.Errorf("expected the error to explain how to fix the API token, got: %s", err)
+	}
+
+	var apiErr *apiError
+
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected the error to unwrap to the API error, got: %#v", err)
+	}
+}
+
+func TestListSources_apiError(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+
+	_, err := listSources(context.Background(), client)
+
+	diags := frameworkAPIErrorDiagnostics("Unable to list sources", err)
+
+	if !diags.HasError() {
+		t.Fatal("expected an error diagnostic")
+	}
+
+	if detail := diags[0].Detail(); !strings.Contains(detail, "plan") {
+		t.Errorf("expected the detail to explain the plan limits, got %q", detail)
+	}
+}
+
+func TestReadSource_removed(t *testing.T) {
+	cases := map[string]http.HandlerFunc{
+		"not found": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		},
+		"archived": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"id":"abc","type":"AWS::Keys","state":"archived"}`)
+		},
+	}
+
+	for name, handler := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, handler)
+
+			d := schema.TestResourceDataRaw(t, resourceHavaSourceAWSKey().Schema, map[string]any{})
+			d.SetId("abc")
+
+			source, err := readSource(context.Background(), client, "abc")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if source != nil {
+				t.Errorf("expected no source to be returned, got %+v", source)
+			}
+
+			if diags := sdkReadSource(context.Background(), d, client, sourceKindAWSKey); diags.HasError() {
+				t.Fatalf("unexpected error: %+v", diags)
+			}
+
+			if d.Id() != "" {
+				t.Errorf("expected the source to be removed from state, id is still %q", d.Id())
+			}
+		})
+	}
+}
+
+func TestReadSource_error(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	d := schema.TestResourceDataRaw(t, resourceHavaSourceAWSKey().Schema, map[string]any{})
+	d.SetId("abc")
+
+	if diags := sdkReadSource(context.Background(), d, client, sourceKindAWSKey); !diags.HasError() {
+		t.Fatal("expected an error for a server error response")
+	}
+
+	if d.Id() != "abc" {
+		t.Errorf("expected the source to be kept in state, id is %q", d.Id())
+	}
+}
+
+func TestWaitForSourceImport(t *testing.T) {
+	sourceImportPollInterval = time.Millisecond
+	t.Cleanup(func() { sourceImportPollInterval = 10 * time.Second })
+
+	cases := map[string]struct {
+		states  []string
+		wantErr string
+	}{
+		"active":  {states: []string{"queued", "importing", "enriching", "active"}},
+		"error":   {states: []string{"queued", "connecting", "error"}, wantErr: "Hava reported the source as 'error'"},
+		"invalid": {states: []string{"invalid"}, wantErr: "Hava reported the source as 'invalid'"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var polls int32
+
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				state := tc.states[min(int(atomic.AddInt32(&polls, 1))-1, len(tc.states)-1)]
+
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"id":"abc","name":"example","type":"AWS::CrossAccountRole","state":%q,"info":"arn:aws:iam::123456789012:role/Hava"}`, state)
+			}))
+
+			source, err := waitForSourceImport(context.Background(), client, "abc", time.Minute)
+
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected an error containing %q, got: %v", tc.wantErr, err)
+			} else if !strings.Contains(err.Error(), "'example' (abc)") || strings.Contains(err.Error(), "arn:aws") {
+				t.Errorf("expected the error to name the source by name and ID only, got: %s", err)
+			}
+
+			if want := tc.states[len(tc.states)-1]; source.GetState() != want {
+				t.Errorf("expected the source to end up %q, got %q", want, source.GetState())
+			}
+		})
+	}
+}
+
+func TestWaitForSourceImport_timeout(t *testing.T) {
+	sourceImportPollInterval = time.Millisecond
+	t.Cleanup(func() { sourceImportPollInterval = 10 * time.Second })
+
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"abc","type":"AWS::CrossAccountRole","state":"importing"}`)
+	}))
+
+	_, err := waitForSourceImport(context.Background(), client, "abc", 50*time.Millisecond)
+
+	if err == nil || !strings.Contains(err.Error(), "importing") {
+		t.Fatalf("expected a timeout error mentioning the last state, got: %v", err)
+	}
+}
+
+func TestSourceDiagnostics_timeout(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+
+	r := &sourceResource{client: client}
+	s := testResourceSchema(t, r)
+	state := tfsdk.State{Schema: s, Raw: testResourceValue(t, s, map[string]any{"id": "abc", "name": "slow"})}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	resp := resource.ReadResponse{State: state}
+
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error when the read times out")
+	}
+
+	if want := "Timed out waiting to read source 'slow' (abc)"; resp.Diagnostics[0].Summary() != want {
+		t.Errorf("expected summary %q, got %q", want, resp.Diagnostics[0].Summary())
+	}
+}
+
+func TestConfigString(t *testing.T) {
+	config := cty.ObjectVal(map[string]cty.Value{
+		"name": cty.StringVal("example"),
+		"aws_access_key": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"secret_key":    cty.NullVal(cty.String),
+				"secret_key_wo": cty.StringVal("write-only"),
+			}),
+		}),
+	})
+
+	for key, want := range map[string]string{
+		"name":                                  "example",
+		"aws_access_key.0.secret_key_wo":        "write-only",
+		"aws_access_key.0.secret_key":           "",
+		"aws_access_key.1.secret_key_wo":        "",
+		"gcp_service_account.0.encoded_file_wo": "",
+	} {
+		if got := configString(config, key); got != want {
+			t.Errorf("expected %q for %s, got %q", want, key, got)
+		}
+	}
+
+	if got := configString(cty.NullVal(config.Type()), "name"); got != "" {
+		t.Errorf("expected an empty string for a null config, got %q", got)
+	}
+}
+
+func TestDeleteSource_deletionProtection(t *testing.T) {
+	for _, protected := range []bool{true, false} {
+		t.Run(fmt.Sprintf("protected=%t", protected), func(t *testing.T) {
+			var deletes atomic.Int32
+
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodDelete {
+					deletes.Add(1)
+				}
+
+				w.WriteHeader(http.StatusNoContent)
+			}))
+
+			diags := deleteSource(context.Background(), client, sourceRequest{ID: "abc", Name: "example"}, protected)
+
+			if protected {
+				if !diags.HasError() {
+					t.Fatal("expected deleting a protected source to fail")
+				}
+
+				if !diags[0].AttributePath.Equals(cty.GetAttrPath("deletion_protection")) {
+					t.Errorf("expected the error to point at deletion_protection, got %#v", diags[0].AttributePath)
+				}
+
+				if deletes.Load() != 0 {
+					t.Error("expected no delete request to be sent for a protected source")
+				}
+
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %+v", diags)
+			}
+
+			if deletes.Load() != 1 {
+				t.Errorf("expected 1 delete request, got %d", deletes.Load())
+			}
+		})
+	}
+}
+
+func TestCreateSource_adoptExisting(t *testing.T) {
+	awsCAR := map[string]any{
+		"aws_cross_account_role.0.role_arn":    "arn:aws:iam::123456789012:role/HavaRO",
+		"aws_cross_account_role.0.external_id": "abc",
+	}
+
+	cases := map[string]struct {
+		sources string
+		// credentials of the source to create, the AWS cross account role when nil
+		credentials map[string]any
+		wantID      string
+		wantError   bool
+	}{
+		"same credentials": {
+			sources: `[
+				{"id":"other","name":"example","type":"AWS::Keys","state":"active"},
+				{"id":"same-role","name":"hand made","type":"AWS::CrossAccountRole","state":"active","info":"arn:aws:iam::123456789012:role/HavaRO"}
+			]`,
+			wantID: "same-role",
+		},
+		"same name": {
+			sources: `[
+				{"id":"archived","name":"example","type":"AWS::CrossAccountRole","state":"archived"},
+				{"id":"same-name","name":"example","type":"AWS::CrossAccountRole","state":"active"}
+			]`,
+			wantID: "same-name",
+		},
+		"same name, other credentials": {
+			sources: `[
+				{"id":"same-name","name":"example","type":"AWS::CrossAccountRole","state":"active","info":"arn:aws:iam::123456789012:role/Other"}
+			]`,
+			wantError: true,
+		},
+		"ambiguous": {
+			sources: `[
+				{"id":"first","name":"example","type":"AWS::CrossAccountRole","state":"active"},
+				{"id":"second","name":"example","type":"AWS::CrossAccountRole","state":"error"}
+			]`,
+			wantError: true,
+		},
+		"no match": {
+			sources: `[{"id":"other","name":"example","type":"AWS::Keys","state":"active"}]`,
+			wantID:  "created",
+		},
+		"Azure, same name": {
+			sources: `[{"id":"same-name","name":"example","type":"Azure::Credentials","state":"active"}]`,
+			credentials: map[string]any{
+				"azure_service_principal.0.subscription_id": "00000000-0000-0000-0000-000000000001",
+				"azure_service_principal.0.tenant_id":       "00000000-0000-0000-0000-000000000002",
+				"azure_service_principal.0.client_id":       "00000000-0000-0000-0000-000000000003",
+				"azure_service_principal.0.secret_key":      "secret",
+			},
+			wantError: true,
+		},
+		"GCP, same name": {
+			sources: `[{"id":"same-name","name":"example","type":"GCP::ServiceAccountCredentials","state":"active"}]`,
+			credentials: map[string]any{
+				"gcp_service_account.0.encoded_file": testGCPServiceAccountKey("example-project"),
+			},
+			wantError: true,
+		},
+		"GCP, no match": {
+			sources: `[{"id":"other","name":"other","type":"GCP::ServiceAccountCredentials","state":"active"}]`,
+			credentials: map[string]any{
+				"gcp_service_account.0.encoded_file": testGCPServiceAccountKey("example-project"),
+			},
+			wantID: "created",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var updated string
+
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/sources":
+					fmt.Fprintf(w, `{"results":%s}`, tc.sources)
+				case r.Method == http.MethodPut:
+					updated = strings.TrimPrefix(r.URL.Path, "/sources/")
+					fmt.Fprintf(w, `{"id":%q,"name":"example","type":"AWS::CrossAccountRole","state":"active"}`, updated)
+				case r.Method == http.MethodPost:
+					fmt.Fprint(w, `{"id":"created","name":"example","type":"AWS::CrossAccountRole","state":"queued"}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+
+			values := map[string]any{
+				"name":           "example",
+				"adopt_existing": true,
+			}
+
+			if tc.credentials == nil {
+				maps.Copy(values, awsCAR)
+			} else {
+				maps.Copy(values, tc.credentials)
+			}
+
+			state, diags := testCreateSource(t, client, values)
+
+			if tc.wantError {
+				if !diags.HasError() {
+					t.Fatal("expected an error when the existing source can't be adopted")
+				}
+
+				// the info of a source is a role ARN or access key ID, which must not be echoed back
+				if detail := diags[0].Detail(); strings.Contains(detail, "arn:aws") {
+					t.Errorf("expected the error not to include the credentials of the existing source, got %q", detail)
+				}
+
+				if updated != "" {
+					t.Errorf("expected the existing source not to be updated, updated %q", updated)
+				}
+
+				if !state.Raw.IsNull() {
+					t.Errorf("expected no source to be stored, got %s", state.Raw)
+				}
+
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %+v", diags)
+			}
+
+			var id string
+
+			if diags := state.GetAttribute(context.Background(), path.Root("id"), &id); diags.HasError() {
+				t.Fatalf("unexpected error: %+v", diags)
+			}
+
+			if id != tc.wantID {
+				t.Errorf("expected id %q, got %q", tc.wantID, id)
+			}
+
+			adopted := tc.wantID != "created"
+
+			if adopted && (len(diags) != 1 || diags[0].Severity() != fwdiag.SeverityWarning) {
+				t.Errorf("expected a warning about the adoption, got %+v", diags)
+			}
+
+			if adopted && updated != tc.wantID {
+				t.Errorf("expected the adopted source to be updated, updated %q", updated)
+			}
+
+			if !adopted && len(diags) != 0 {
+				t.Errorf("expected no diagnostics when creating a source, got %+v", diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// httpLoggingEnvVar is the environment variable that sets the log level of the provider.
+// Requests to the Hava API are only logged when it is set to DEBUG or TRACE, so they don't
+// show up in logs where TF_LOG is turned up for Terraform itself.
+const httpLoggingEnvVar = "TF_LOG_PROVIDER_HAVA"
+
+// httpLoggingEnabled reports if requests to the Hava API should be logged
+func httpLoggingEnabled() bool {
+	return slices.Contains([]string{"DEBUG", "TRACE"}, strings.ToUpper(os.Getenv(httpLoggingEnvVar)))
+}
+
+// loggingTransport logs the method, path, status and latency of every request sent to the
+// Hava API. Neither headers nor bodies are logged, and the API token is masked in case it
+// turns up in an error.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func newLoggingTransport(next http.RoundTripper) *loggingTransport {
+	return &loggingTransport{
+		next: next,
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer "); ok && token != "" {
+		ctx = tflog.MaskMessageStrings(ctx, token)
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, token)
+	}
+
+	start := time.Now()
+
+	res, err := t.next.RoundTrip(req)
+
+	fields := map[string]any{
+		"method":     req.Method,
+		"path":       req.URL.Path,
+		"latency_ms": time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+
+		tflog.Debug(ctx, fmt.Sprintf("%s %s failed", req.Method, req.URL.Path), fields)
+
+		return res, err
+	}
+
+	fields["status"] = res.StatusCode
+
+	tflog.Debug(ctx, fmt.Sprintf("%s %s %d", req.Method, req.URL.Path, res.StatusCode), fields)
+
+	return res, nil
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	havaclient "github.com/teamhava/hava-sdk-go"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	var output bytes.Buffer
+
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/sources/secret-token", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header.Set("Authorization", "Bearer secret-token")
+
+	res, err := newLoggingTransport(http.DefaultTransport).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	res.Body.Close()
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+
+	entry := entries[0]
+
+	if entry["method"] != "GET" || entry["status"] != float64(http.StatusNotFound) {
+		t.Errorf("expected the method and status to be logged, got %v", entry)
+	}
+
+	if _, ok := entry["latency_ms"]; !ok {
+		t.Errorf("expected the latency to be logged, got %v", entry)
+	}
+
+	if strings.Contains(output.String(), "secret-token") {
+		t.Errorf("expected the API token to be masked, got %s", output.String())
+	}
+}
+
+func TestLogSourceRequest(t *testing.T) {
+	var output bytes.Buffer
+
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	name, sourceType, roleARN, externalID := "example", sourceTypeAWSCAR, "arn:aws:iam::123456789012:role/HavaRO", "secret-external-id"
+
+	logSourceRequest(ctx, "creating source", havaclient.SourcesAWSCARAsSourcesCreateRequest(&havaclient.SourcesAWSCAR{
+		Name:       &name,
+		Type:       &sourceType,
+		RoleArn:    &roleARN,
+		ExternalId: &externalID,
+	}))
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+
+	if entries[0]["name"] != "example" {
+		t.Errorf("expected the name to be logged, got %v", entries[0])
+	}
+
+	for _, secret := range []string{roleARN, externalID} {
+		if strings.Contains(output.String(), secret) {
+			t.Errorf("expected %q to be masked, got %s", secret, output.String())
+		}
+	}
+}
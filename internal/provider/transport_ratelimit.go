@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// Descriptions of the provider attributes that limit the requests to the Hava API, shared by
+// the SDKv2 and framework provider schemas
+const (
+	requestsPerSecondDescription     = "Maximum number of requests per second sent to the Hava API, shared by all resources managed by the provider. Set to `0` to not limit the request rate. Defaults to `0`."
+	maxConcurrentRequestsDescription = "Maximum number of requests to the Hava API in flight at the same time, shared by all resources managed by the provider. Set to `0` to not limit concurrency. Defaults to `0`."
+)
+
+// rateLimitTransport limits the rate of requests sent to the Hava API with a token bucket, and
+// the number of requests in flight at the same time with a semaphore. Terraform runs several
+// operations in parallel against the same client, so this is what keeps a large apply within
+// the API rate limits.
+type rateLimitTransport struct {
+	next http.RoundTripper
+
+	// limiter is nil when the request rate is not limited
+	limiter *rate.Limiter
+	// semaphore is nil when the number of concurrent requests is not limited
+	semaphore chan struct{}
+}
+
+func newRateLimitTransport(next http.RoundTripper, requestsPerSecond float64, maxConcurrentRequests int) *rateLimitTransport {
+	t := &rateLimitTransport{
+		next: next,
+	}
+
+	if requestsPerSecond > 0 {
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), int(math.Max(1, math.Ceil(requestsPerSecond))))
+	}
+
+	if maxConcurrentRequests > 0 {
+		t.semaphore = make(chan struct{}, maxConcurrentRequests)
+	}
+
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+
+	release := func() {}
+
+	if t.semaphore != nil {
+		select {
+		case t.semaphore <- struct{}{}:
+			var once sync.Once
+			release = func() { once.Do(func() { <-t.semaphore }) }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			release()
+
+			if ctx.Err() == nil {
+				// the limiter gives up early when the next token is due after the deadline
+				return nil, fmt.Errorf("%w: %s", context.DeadlineExceeded, err)
+			}
+
+			return nil, err
+		}
+	}
+
+	if waited := time.Since(start); waited >= time.Millisecond {
+		tflog.Debug(ctx, fmt.Sprintf("throttled %s %s for %s", req.Method, req.URL.Path, waited.Round(time.Millisecond)))
+	}
+
+	resp, err := t.next.RoundTrip(req)
+
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// the request is in flight until its body has been read
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// releasingBody calls release once the response body is closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+
+	return b.ReadCloser.Close()
+}
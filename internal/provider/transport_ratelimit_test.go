@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRateLimitTransport sends parallel requests through the provider's HTTP client, like
+// terraform does during an apply, and checks that the configured limits hold.
+func TestRateLimitTransport(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"abc","state":"active"}`)
+	}))
+	defer server.Close()
+
+	config := providerConfig{
+		APIToken:              "test",
+		Endpoint:              server.URL,
+		RequestsPerSecond:     50,
+		MaxConcurrentRequests: 2,
+	}
+
+	client, err := config.client(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	const requests = 20
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, _, err := client.SourcesApi.SourcesShow(context.Background(), "abc").Execute(); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+
+	// the bucket starts with a burst of 50 requests, so only the concurrency limit slows this
+	// down: 20 requests, 2 at a time, 10ms each
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected the requests to take at least 100ms, took %s", elapsed)
+	}
+}
+
+func TestRateLimitTransport_requestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport := newRateLimitTransport(http.DefaultTransport, 10, 0)
+
+	start := time.Now()
+
+	// the first 10 requests use up the burst, the 3 after that have to wait for new tokens
+	var wg sync.WaitGroup
+	for i := 0; i < 13; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 250*time.Millisecond {
+		t.Errorf("expected the requests to take at least 250ms at 10 requests per second, took %s", elapsed)
+	}
+}
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// retryMinWait is the wait before the first retry, it doubles for every following retry
+const retryMinWait = 1 * time.Second
+
+// Defaults of the max_retries and retry_max_wait provider attributes
+const (
+	defaultMaxRetries   = 4
+	defaultRetryMaxWait = 30 * time.Second
+)
+
+// Descriptions of the provider attributes that configure retries, shared by the SDKv2 and
+// framework provider schemas
+const (
+	maxRetriesDescription   = "Maximum number of times a request to the Hava API is retried after a transient error, such as a rate limit or server error. Set to `0` to disable retries. Defaults to `4`."
+	retryMaxWaitDescription = "Maximum number of seconds to wait before retrying a request to the Hava API. Defaults to `30`."
+)
+
+// retryTransport retries requests to the Hava API that fail with a transient error, waiting
+// with exponential backoff and jitter between attempts, or as long as the API asks through the
+// Retry-After header.
+//
+// Only requests that are safe to send again are retried: idempotent requests, and requests the
+// API is known not to have processed, i.e. those that were rate limited or that never made it
+// to the server.
+type retryTransport struct {
+	next http.RoundTripper
+
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, maxRetries int, maxWait time.Duration) *retryTransport {
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		minWait:    retryMinWait,
+		maxWait:    maxWait,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+
+		wait, retry := t.retryAfter(req, resp, err, attempt)
+
+		if !retry {
+			return resp, err
+		}
+
+		var reason string
+
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+
+			// read the rest of the body so the connection can be reused
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("retrying %s %s in %s after %s", req.Method, req.URL.Path, wait, reason), map[string]any{
+			"attempt":     attempt + 1,
+			"max_retries": t.maxRetries,
+		})
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryAfter decides if a request should be retried, and if so how long to wait first
+func (t *retryTransport) retryAfter(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= t.maxRetries || req.Context().Err() != nil {
+		return 0, false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// the body can't be sent again
+		return 0, false
+	}
+
+	wait := t.backoff(attempt)
+
+	if err != nil {
+		if isIdempotent(req.Method) || isDialError(err) {
+			return wait, true
+		}
+
+		return 0, false
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		// rate limited requests have not been processed, so they are safe to retry
+	case resp.StatusCode == http.StatusServiceUnavailable, resp.StatusCode == http.StatusBadGateway, resp.StatusCode == http.StatusGatewayTimeout, resp.StatusCode == http.StatusInternalServerError:
+		if !isIdempotent(req.Method) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		if retryAfter > t.maxWait {
+			// the API asked us to back off for longer than we are allowed to wait
+			return 0, false
+		}
+
+		wait = retryAfter
+	}
+
+	return wait, true
+}
+
+// backoff returns the exponential backoff with jitter for the given attempt
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	wait := t.maxWait
+
+	if attempt < 30 && t.minWait<<attempt < t.maxWait {
+		wait = t.minWait << attempt
+	}
+
+	// wait somewhere between half and the full backoff, so parallel requests spread out
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of
+// seconds or an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// isDialError reports if err happened while connecting to the API, before any of the request
+// was sent
+func isDialError(err error) bool {
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
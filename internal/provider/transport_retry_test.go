@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	cases := map[string]struct {
+		method       string
+		status       int
+		retryAfter   string
+		wantAttempts int32
+	}{
+		"idempotent request is retried on server error": {
+			method:       http.MethodGet,
+			status:       http.StatusServiceUnavailable,
+			wantAttempts: 3,
+		},
+		"non idempotent request is not retried on server error": {
+			method:       http.MethodPost,
+			status:       http.StatusInternalServerError,
+			wantAttempts: 1,
+		},
+		"rate limited request is retried": {
+			method:       http.MethodPost,
+			status:       http.StatusTooManyRequests,
+			retryAfter:   "0",
+			wantAttempts: 3,
+		},
+		"retry after longer than max wait is not retried": {
+			method:       http.MethodGet,
+			status:       http.StatusTooManyRequests,
+			retryAfter:   "3600",
+			wantAttempts: 1,
+		},
+		"client error is not retried": {
+			method:       http.MethodGet,
+			status:       http.StatusUnprocessableEntity,
+			wantAttempts: 1,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var attempts int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+
+				if c.retryAfter != "" {
+					w.Header().Set("Retry-After", c.retryAfter)
+				}
+
+				w.WriteHeader(c.status)
+			}))
+			defer server.Close()
+
+			transport := newRetryTransport(http.DefaultTransport, 2, time.Second)
+			transport.minWait = time.Millisecond
+
+			req, _ := http.NewRequest(c.method, server.URL, strings.NewReader(`{"name":"example"}`))
+
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+
+			if got := atomic.LoadInt32(&attempts); got != c.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", c.wantAttempts, got)
+			}
+
+			if resp.StatusCode != c.status {
+				t.Errorf("expected the last response to be returned, got status %d", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_resendsBody(t *testing.T) {
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	transport := newRetryTransport(http.DefaultTransport, 2, time.Second)
+	transport.minWait = time.Millisecond
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"name":"example"}`))
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+
+	if len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Errorf("expected the same body to be sent twice, got %q", bodies)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if wait, ok := parseRetryAfter("5"); !ok || wait != 5*time.Second {
+		t.Errorf("expected 5s, got %s, %t", wait, ok)
+	}
+
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if wait, ok := parseRetryAfter(date); !ok || wait <= 0 || wait > 10*time.Second {
+		t.Errorf("expected up to 10s for %q, got %s, %t", date, wait, ok)
+	}
+
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("expected an invalid value to be ignored")
+	}
+}
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"log"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 	"github.com/teamhava/terraform-provider-hava/internal/provider"
 )
 
@@ -32,14 +34,24 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{
-		Debug: debugMode,
+	// the provider is served by both terraform-plugin-sdk and terraform-plugin-framework while
+	// it is migrated to the framework, see provider.NewMuxServer
+	serverFactory, err := provider.NewMuxServer(context.Background(), version)
 
-		// TODO: update this string with the full name of your provider as used in your configs
-		ProviderAddr: "registry.terraform.io/havio/hava",
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf6server.ServeOpt
 
-		ProviderFunc: provider.New(version),
+	if debugMode {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
 	}
 
-	plugin.Serve(opts)
+	// TODO: update this string with the full name of your provider as used in your configs
+	err = tf6server.Serve("registry.terraform.io/havio/hava", serverFactory, serveOpts...)
+
+	if err != nil {
+		log.Fatal(err)
+	}
 }